@@ -7,6 +7,8 @@ import (
 
 	uuid "github.com/satori/go.uuid"
 
+	"github.com/gbl08ma/ankiddie/config"
+	"github.com/gbl08ma/ankiddie/notify"
 	"github.com/gbl08ma/anko/env"
 )
 
@@ -21,6 +23,12 @@ type Ankiddie struct {
 	envs  map[uint]*Environment
 	curID uint
 	store ScriptPersister
+
+	// notifierMu guards notifier. It is kept separate from m because ssys.notify is
+	// called both with m held (from Start, Suspend, Restart and ForgetEnv) and without
+	// it (from Environment.Start/Restart/Execute's error paths), so it cannot take m itself.
+	notifierMu sync.RWMutex
+	notifier   notify.Notifier
 }
 
 // PackageConfigurator configures additional packages to expose to anko environments
@@ -43,6 +51,14 @@ func New(configurator PackageConfigurator, store ScriptPersister) *Ankiddie {
 	return ankiddie
 }
 
+// SetNotifier configures n as the destination for lifecycle and error events raised
+// by every environment subsequently created on this Ankiddie. A nil n disables notifications.
+func (ssys *Ankiddie) SetNotifier(n notify.Notifier) {
+	ssys.notifierMu.Lock()
+	defer ssys.notifierMu.Unlock()
+	ssys.notifier = n
+}
+
 // NewEnvWithCode returns a new Environment ready to run the provided code
 func (ssys *Ankiddie) NewEnvWithCode(code string, out func(env *Environment, msg string) error) *Environment {
 	ssys.m.Lock()
@@ -59,6 +75,15 @@ func (ssys *Ankiddie) NewEnvWithScript(script *Script, out func(env *Environment
 	defer ssys.m.Unlock()
 	env := ssys.newEnv(ssys.curID, script.Code, out)
 	env.scriptID = script.ID
+	env.notifyPolicy = script.NotifyPolicy
+	if len(script.Params) > 0 {
+		// errors are swallowed here because a malformed default/persisted value
+		// shouldn't prevent the script from running; the env then has no ParamStore,
+		// so param() returns nil for every parameter
+		if store, err := config.NewStore(ssys.store, script.ID, script.Params); err == nil {
+			env.params = store
+		}
+	}
 	ssys.envs[env.eid] = env
 	ssys.curID++
 	return env
@@ -88,6 +113,11 @@ func (ssys *Ankiddie) ForgetEnv(env *Environment) {
 	ssys.m.Lock()
 	defer ssys.m.Unlock()
 	env.cancel()
+	env.emit(EventForgotten)
+	env.notify(notify.Forgotten, notify.SeverityInfo, "")
+	for _, unsubscribe := range env.paramUnsubs {
+		unsubscribe()
+	}
 	delete(ssys.envs, env.eid)
 }
 
@@ -101,7 +131,9 @@ func (ssys *Ankiddie) FullReset() {
 	ssys.envs = make(map[uint]*Environment)
 }
 
-// StartAutorun executes scripts at the specified autorun level
+// StartAutorun executes scripts at the specified autorun level. If async, each script is
+// started on its own goroutine; errors and panics from those goroutines cannot be returned
+// to the caller, so they are instead surfaced as notify.Errored/notify.Panicked events.
 func (ssys *Ankiddie) StartAutorun(level int, async bool, out func(env *Environment, msg string) error) error {
 	if ssys.store == nil {
 		return ErrNoPersister
@@ -115,14 +147,23 @@ func (ssys *Ankiddie) StartAutorun(level int, async bool, out func(env *Environm
 	for _, script := range scripts {
 		env := ssys.NewEnvWithScript(script, out)
 		if async {
-			go env.Start()
+			go env.startForAutorun()
 		} else {
-			env.Start()
+			env.startForAutorun()
 		}
 	}
+	ssys.notify(0, "", notify.AutorunFinished, notify.SeverityInfo, "", "", nil)
 	return nil
 }
 
+// GetScript retrieves a script from the database by ID
+func (ssys *Ankiddie) GetScript(id string) (*Script, error) {
+	if ssys.store == nil {
+		return nil, ErrNoPersister
+	}
+	return ssys.store.GetScript(id)
+}
+
 // SaveScript saves a script to the database under the specified ID
 // If no ID is provided, a UUID is generated
 // If a script with the same ID already existed, it is overwritten
@@ -0,0 +1,91 @@
+// Package api exposes an *ankiddie.Ankiddie manager over HTTP, with a WebSocket
+// bridge for streaming environment output and driving an interactive REPL remotely.
+package api
+
+import (
+	"net/http"
+
+	"github.com/gbl08ma/ankiddie"
+	"github.com/gorilla/mux"
+)
+
+// Permission identifies a capability an authenticated caller may be granted
+type Permission int
+
+const (
+	// PermRead allows listing environments and scripts, and reading their state
+	PermRead Permission = iota
+	// PermExecute allows suspending and forgetting environments, saving scripts and triggering autorun
+	PermExecute
+	// PermMonkeyPatch allows calls that can arbitrarily mutate the host process: creating,
+	// starting, restarting and executing code in environments
+	PermMonkeyPatch
+)
+
+// User identifies a caller that has been authenticated against the API
+type User interface {
+	// HasPermission returns whether this user is allowed to perform actions requiring perm
+	HasPermission(perm Permission) bool
+}
+
+// Authenticator authenticates incoming HTTP requests
+type Authenticator interface {
+	// Authenticate returns the User associated with r, or an error if the request could not be authenticated
+	Authenticate(r *http.Request) (User, error)
+}
+
+// Server exposes an Ankiddie instance over HTTP and WebSocket
+type Server struct {
+	ankiddie *ankiddie.Ankiddie
+	auth     Authenticator
+	hub      *hub
+}
+
+// NewServer returns a Server wrapping the given Ankiddie instance.
+// Every request is authenticated using auth before being handled.
+func NewServer(a *ankiddie.Ankiddie, auth Authenticator) *Server {
+	return &Server{
+		ankiddie: a,
+		auth:     auth,
+		hub:      newHub(),
+	}
+}
+
+// Handler returns the http.Handler implementing the API routes
+func (s *Server) Handler() http.Handler {
+	r := mux.NewRouter()
+
+	// create, start and restart all run an environment's full source, which can itself
+	// call monkeyPatch()/monkeyPatchTypeMethod(), so they require PermMonkeyPatch just
+	// like execute does - gating only execute would leave this wide open via start/restart.
+	r.HandleFunc("/envs", s.withUser(PermRead, s.handleListEnvs)).Methods(http.MethodGet)
+	r.HandleFunc("/envs", s.withUser(PermMonkeyPatch, s.handleCreateEnv)).Methods(http.MethodPost)
+	r.HandleFunc("/envs/{eid}/start", s.withUser(PermMonkeyPatch, s.handleStartEnv)).Methods(http.MethodPost)
+	r.HandleFunc("/envs/{eid}/suspend", s.withUser(PermExecute, s.handleSuspendEnv)).Methods(http.MethodPost)
+	r.HandleFunc("/envs/{eid}/restart", s.withUser(PermMonkeyPatch, s.handleRestartEnv)).Methods(http.MethodPost)
+	r.HandleFunc("/envs/{eid}/forget", s.withUser(PermExecute, s.handleForgetEnv)).Methods(http.MethodPost)
+	r.HandleFunc("/envs/{eid}/execute", s.withUser(PermMonkeyPatch, s.handleExecuteEnv)).Methods(http.MethodPost)
+	r.HandleFunc("/envs/{eid}/stream", s.withUser(PermRead, s.handleStream))
+	r.HandleFunc("/scripts/{id}", s.withUser(PermRead, s.handleGetScript)).Methods(http.MethodGet)
+	r.HandleFunc("/scripts/{id}", s.withUser(PermExecute, s.handleSaveScript)).Methods(http.MethodPut)
+	r.HandleFunc("/autorun/{level}", s.withUser(PermExecute, s.handleAutorun)).Methods(http.MethodPost)
+
+	return r
+}
+
+// withUser authenticates the request, checks that the resulting User has perm, and
+// only then calls next. Unauthenticated or unauthorized requests get 401/403 respectively.
+func (s *Server) withUser(perm Permission, next func(http.ResponseWriter, *http.Request, User)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, err := s.auth.Authenticate(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if !user.HasPermission(perm) {
+			http.Error(w, "insufficient permissions", http.StatusForbidden)
+			return
+		}
+		next(w, r, user)
+	}
+}
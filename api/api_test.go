@@ -0,0 +1,127 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gbl08ma/ankiddie"
+)
+
+type fakeUser struct {
+	perms map[Permission]bool
+}
+
+func (u fakeUser) HasPermission(perm Permission) bool {
+	return u.perms[perm]
+}
+
+// fakeAuth authenticates every request as a fixed User, or fails if fail is set
+type fakeAuth struct {
+	user fakeUser
+	fail bool
+}
+
+func (a fakeAuth) Authenticate(r *http.Request) (User, error) {
+	if a.fail {
+		return nil, errors.New("not authenticated")
+	}
+	return a.user, nil
+}
+
+func newTestServer(user fakeUser, fail bool) *Server {
+	return NewServer(ankiddie.New(nil, nil), fakeAuth{user: user, fail: fail})
+}
+
+func TestWithUserUnauthenticated(t *testing.T) {
+	called := false
+	s := newTestServer(fakeUser{}, true)
+	h := s.withUser(PermRead, func(w http.ResponseWriter, r *http.Request, u User) { called = true })
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/envs", nil))
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+	if called {
+		t.Error("next was called despite a failed authentication")
+	}
+}
+
+func TestWithUserInsufficientPermission(t *testing.T) {
+	called := false
+	s := newTestServer(fakeUser{perms: map[Permission]bool{PermRead: true}}, false)
+	h := s.withUser(PermMonkeyPatch, func(w http.ResponseWriter, r *http.Request, u User) { called = true })
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodPost, "/envs", nil))
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+	if called {
+		t.Error("next was called despite insufficient permission")
+	}
+}
+
+func TestWithUserAllowed(t *testing.T) {
+	called := false
+	s := newTestServer(fakeUser{perms: map[Permission]bool{PermRead: true}}, false)
+	h := s.withUser(PermRead, func(w http.ResponseWriter, r *http.Request, u User) { called = true })
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/envs", nil))
+
+	if w.Code != http.StatusOK && w.Code != 0 {
+		t.Errorf("status = %d, want default/200", w.Code)
+	}
+	if !called {
+		t.Error("next was not called despite sufficient permission")
+	}
+}
+
+// TestRouteTablePermissions asserts the permission each route in Handler() requires,
+// guarding against routes silently being gated on the wrong tier (e.g. the
+// create/start/restart/execute monkey-patch bypass fixed in chunk0-1).
+func TestRouteTablePermissions(t *testing.T) {
+	cases := []struct {
+		method string
+		path   string
+		perm   Permission
+	}{
+		{http.MethodGet, "/envs", PermRead},
+		{http.MethodPost, "/envs", PermMonkeyPatch},
+		{http.MethodPost, "/envs/1/start", PermMonkeyPatch},
+		{http.MethodPost, "/envs/1/suspend", PermExecute},
+		{http.MethodPost, "/envs/1/restart", PermMonkeyPatch},
+		{http.MethodPost, "/envs/1/forget", PermExecute},
+		{http.MethodPost, "/envs/1/execute", PermMonkeyPatch},
+		{http.MethodGet, "/scripts/x", PermRead},
+		{http.MethodPut, "/scripts/x", PermExecute},
+		{http.MethodPost, "/autorun/1", PermExecute},
+	}
+
+	for _, c := range cases {
+		// A user with every permission except the one required should be rejected...
+		allPermsExceptRequired := fakeUser{perms: map[Permission]bool{PermRead: true, PermExecute: true, PermMonkeyPatch: true}}
+		allPermsExceptRequired.perms[c.perm] = false
+
+		s := newTestServer(allPermsExceptRequired, false)
+		w := httptest.NewRecorder()
+		s.Handler().ServeHTTP(w, httptest.NewRequest(c.method, c.path, nil))
+		if w.Code != http.StatusForbidden {
+			t.Errorf("%s %s without %v: status = %d, want %d", c.method, c.path, c.perm, w.Code, http.StatusForbidden)
+		}
+
+		// ...but a user missing only some other permission should not be rejected for this one
+		onlyRequired := fakeUser{perms: map[Permission]bool{c.perm: true}}
+		s = newTestServer(onlyRequired, false)
+		w = httptest.NewRecorder()
+		s.Handler().ServeHTTP(w, httptest.NewRequest(c.method, c.path, nil))
+		if w.Code == http.StatusForbidden {
+			t.Errorf("%s %s with only %v: status = %d, want not 403", c.method, c.path, c.perm, w.Code)
+		}
+	}
+}
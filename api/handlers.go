@@ -0,0 +1,217 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gbl08ma/ankiddie"
+	"github.com/gorilla/mux"
+)
+
+// envView is the JSON representation of an ankiddie.Environment returned by the API
+type envView struct {
+	EID       uint   `json:"eid"`
+	ScriptID  string `json:"scriptID,omitempty"`
+	Started   bool   `json:"started"`
+	Suspended bool   `json:"suspended"`
+	Dirty     bool   `json:"dirty"`
+}
+
+func newEnvView(env *ankiddie.Environment) envView {
+	return envView{
+		EID:       env.EID(),
+		ScriptID:  env.ScriptID(),
+		Started:   env.Started(),
+		Suspended: env.Suspended(),
+		Dirty:     env.Dirty(),
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func (s *Server) envFromRequest(w http.ResponseWriter, r *http.Request) (*ankiddie.Environment, bool) {
+	eid, err := strconv.ParseUint(mux.Vars(r)["eid"], 10, 64)
+	if err != nil {
+		http.Error(w, "invalid environment id", http.StatusBadRequest)
+		return nil, false
+	}
+	env, ok := s.ankiddie.Environment(uint(eid))
+	if !ok {
+		http.Error(w, "environment not found", http.StatusNotFound)
+		return nil, false
+	}
+	return env, true
+}
+
+// handleListEnvs handles GET /envs
+func (s *Server) handleListEnvs(w http.ResponseWriter, r *http.Request, user User) {
+	envs := s.ankiddie.Environments()
+	views := make([]envView, 0, len(envs))
+	for _, env := range envs {
+		views = append(views, newEnvView(env))
+	}
+	writeJSON(w, http.StatusOK, views)
+}
+
+// createEnvRequest is the body accepted by POST /envs
+type createEnvRequest struct {
+	Code     string `json:"code"`
+	ScriptID string `json:"scriptID"`
+}
+
+// handleCreateEnv handles POST /envs, creating an environment either from inline code or a stored script
+func (s *Server) handleCreateEnv(w http.ResponseWriter, r *http.Request, user User) {
+	var req createEnvRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	out := s.hub.outFunc()
+
+	var env *ankiddie.Environment
+	switch {
+	case req.ScriptID != "" && req.Code != "":
+		// associate the inline code with scriptID, creating or overwriting it
+		script, err := s.ankiddie.SaveScript(req.ScriptID, req.Code)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		env = s.ankiddie.NewEnvWithScript(script, out)
+	case req.ScriptID != "":
+		script, err := s.ankiddie.GetScript(req.ScriptID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		env = s.ankiddie.NewEnvWithScript(script, out)
+	default:
+		env = s.ankiddie.NewEnvWithCode(req.Code, out)
+	}
+
+	writeJSON(w, http.StatusCreated, newEnvView(env))
+}
+
+func (s *Server) handleStartEnv(w http.ResponseWriter, r *http.Request, user User) {
+	env, ok := s.envFromRequest(w, r)
+	if !ok {
+		return
+	}
+	result, err := env.Start()
+	s.writeExecResult(w, result, err)
+}
+
+func (s *Server) handleSuspendEnv(w http.ResponseWriter, r *http.Request, user User) {
+	env, ok := s.envFromRequest(w, r)
+	if !ok {
+		return
+	}
+	if err := env.Suspend(); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	writeJSON(w, http.StatusOK, newEnvView(env))
+}
+
+func (s *Server) handleRestartEnv(w http.ResponseWriter, r *http.Request, user User) {
+	env, ok := s.envFromRequest(w, r)
+	if !ok {
+		return
+	}
+	result, err := env.Restart()
+	s.writeExecResult(w, result, err)
+}
+
+func (s *Server) handleForgetEnv(w http.ResponseWriter, r *http.Request, user User) {
+	env, ok := s.envFromRequest(w, r)
+	if !ok {
+		return
+	}
+	env.Forget()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// executeRequest is the body accepted by POST /envs/{eid}/execute
+type executeRequest struct {
+	Code        string `json:"code"`
+	AppendToSrc bool   `json:"appendToSrc"`
+}
+
+// handleExecuteEnv handles POST /envs/{eid}/execute. Routed behind PermMonkeyPatch
+// since anko code can call monkeyPatch to arbitrarily mutate the host process.
+func (s *Server) handleExecuteEnv(w http.ResponseWriter, r *http.Request, user User) {
+	env, ok := s.envFromRequest(w, r)
+	if !ok {
+		return
+	}
+	var req executeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	result, err := env.Execute(req.Code, req.AppendToSrc)
+	s.writeExecResult(w, result, err)
+}
+
+// execResult is the JSON representation of the outcome of running anko code
+type execResult struct {
+	Result interface{} `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+func (s *Server) writeExecResult(w http.ResponseWriter, result interface{}, err error) {
+	resp := execResult{Result: result}
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleGetScript(w http.ResponseWriter, r *http.Request, user User) {
+	id := mux.Vars(r)["id"]
+	script, err := s.ankiddie.GetScript(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, script)
+}
+
+// saveScriptRequest is the body accepted by PUT /scripts/{id}
+type saveScriptRequest struct {
+	Code string `json:"code"`
+}
+
+func (s *Server) handleSaveScript(w http.ResponseWriter, r *http.Request, user User) {
+	id := mux.Vars(r)["id"]
+	var req saveScriptRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	script, err := s.ankiddie.SaveScript(id, req.Code)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, script)
+}
+
+func (s *Server) handleAutorun(w http.ResponseWriter, r *http.Request, user User) {
+	level, err := strconv.Atoi(mux.Vars(r)["level"])
+	if err != nil {
+		http.Error(w, "invalid autorun level", http.StatusBadRequest)
+		return
+	}
+	if err := s.ankiddie.StartAutorun(level, true, s.hub.outFunc()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
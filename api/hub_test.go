@@ -0,0 +1,62 @@
+package api
+
+import "testing"
+
+func TestHubBroadcastDeliversToSubscribers(t *testing.T) {
+	h := newHub()
+	ch1 := h.subscribe(1)
+	ch2 := h.subscribe(1)
+	other := h.subscribe(2)
+
+	h.broadcast(1, frame{Type: "output", EID: 1, Output: "hi"})
+
+	select {
+	case f := <-ch1:
+		if f.Output != "hi" {
+			t.Errorf("ch1 got %+v, want Output=hi", f)
+		}
+	default:
+		t.Error("ch1 received nothing")
+	}
+	select {
+	case f := <-ch2:
+		if f.Output != "hi" {
+			t.Errorf("ch2 got %+v, want Output=hi", f)
+		}
+	default:
+		t.Error("ch2 received nothing")
+	}
+	select {
+	case f := <-other:
+		t.Errorf("subscriber of a different eid received %+v", f)
+	default:
+	}
+}
+
+func TestHubUnsubscribeStopsDelivery(t *testing.T) {
+	h := newHub()
+	ch := h.subscribe(1)
+	h.unsubscribe(1, ch)
+
+	h.broadcast(1, frame{Type: "output", EID: 1, Output: "hi"})
+
+	select {
+	case f := <-ch:
+		t.Errorf("unsubscribed channel received %+v", f)
+	default:
+	}
+}
+
+func TestHubBroadcastDropsWhenFull(t *testing.T) {
+	h := newHub()
+	ch := h.subscribe(1)
+
+	// the channel is buffered (32); broadcasting well past that must not block
+	for i := 0; i < 64; i++ {
+		h.broadcast(1, frame{Type: "output", EID: 1, Output: "x"})
+	}
+
+	if len(ch) != cap(ch) {
+		t.Errorf("len(ch) = %d, want the channel full at capacity %d", len(ch), cap(ch))
+	}
+}
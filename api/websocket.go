@@ -0,0 +1,151 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/gbl08ma/ankiddie"
+	"github.com/gorilla/websocket"
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+// frame is a single message sent over an environment's /stream WebSocket
+type frame struct {
+	Type   string `json:"type"` // "output", "event" or "error"
+	EID    uint   `json:"eid"`
+	Output string `json:"output,omitempty"`
+	Event  string `json:"event,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// inboundFrame is a single message accepted from a client over an environment's /stream WebSocket
+type inboundFrame struct {
+	Type        string `json:"type"` // currently only "execute" is supported
+	Code        string `json:"code"`
+	AppendToSrc bool   `json:"appendToSrc"`
+}
+
+var eventNames = map[ankiddie.EventType]string{
+	ankiddie.EventStarted:   "started",
+	ankiddie.EventSuspended: "suspended",
+	ankiddie.EventRestarted: "restarted",
+	ankiddie.EventForgotten: "forgotten",
+}
+
+// hub fans output captured from environments created through the API out to
+// whichever /stream WebSocket connections are currently subscribed to them
+type hub struct {
+	mu   sync.Mutex
+	subs map[uint][]chan frame
+}
+
+func newHub() *hub {
+	return &hub{subs: make(map[uint][]chan frame)}
+}
+
+// outFunc returns an ankiddie out callback that forwards captured output to the hub
+func (h *hub) outFunc() func(env *ankiddie.Environment, msg string) error {
+	return func(env *ankiddie.Environment, msg string) error {
+		h.broadcast(env.EID(), frame{Type: "output", EID: env.EID(), Output: msg})
+		return nil
+	}
+}
+
+func (h *hub) broadcast(eid uint, f frame) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, ch := range h.subs[eid] {
+		select {
+		case ch <- f:
+		default:
+		}
+	}
+}
+
+func (h *hub) subscribe(eid uint) chan frame {
+	ch := make(chan frame, 32)
+	h.mu.Lock()
+	h.subs[eid] = append(h.subs[eid], ch)
+	h.mu.Unlock()
+	return ch
+}
+
+func (h *hub) unsubscribe(eid uint, ch chan frame) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	subs := h.subs[eid]
+	for i, s := range subs {
+		if s == ch {
+			h.subs[eid] = append(subs[:i], subs[i+1:]...)
+			return
+		}
+	}
+}
+
+// handleStream handles GET /envs/{eid}/stream, upgrading the connection to a WebSocket
+// that streams output and lifecycle events for the environment, and accepts execute frames back
+func (s *Server) handleStream(w http.ResponseWriter, r *http.Request, user User) {
+	env, ok := s.envFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	outCh := s.hub.subscribe(env.EID())
+	defer s.hub.unsubscribe(env.EID(), outCh)
+
+	events := make(chan ankiddie.Event, 8)
+	unsubscribe := env.Subscribe(events)
+	defer unsubscribe()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			var in inboundFrame
+			if err := conn.ReadJSON(&in); err != nil {
+				return
+			}
+			if in.Type != "execute" || !user.HasPermission(PermMonkeyPatch) {
+				continue
+			}
+			result, err := env.Execute(in.Code, in.AppendToSrc)
+			resp := frame{Type: "output", EID: env.EID()}
+			if err != nil {
+				resp.Type = "error"
+				resp.Error = err.Error()
+			} else if result != nil {
+				resp.Output = fmt.Sprint(result)
+			} else {
+				continue
+			}
+			s.hub.broadcast(env.EID(), resp)
+		}
+	}()
+
+	for {
+		select {
+		case <-done:
+			return
+		case f := <-outCh:
+			if conn.WriteJSON(f) != nil {
+				return
+			}
+		case ev := <-events:
+			f := frame{Type: "event", EID: ev.EID, Event: eventNames[ev.Type]}
+			if conn.WriteJSON(f) != nil {
+				return
+			}
+		}
+	}
+}
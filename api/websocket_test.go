@@ -0,0 +1,68 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// dialStream opens a WebSocket connection to an httptest server's /envs/{eid}/stream route
+func dialStream(t *testing.T, srv *httptest.Server, eid uint) *websocket.Conn {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/envs/" + strconv.FormatUint(uint64(eid), 10) + "/stream"
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("dial %s: %v", url, err)
+	}
+	return conn
+}
+
+func TestWebSocketExecuteFrameRequiresMonkeyPatchPermission(t *testing.T) {
+	s := newTestServer(fakeUser{perms: map[Permission]bool{PermRead: true, PermExecute: true}}, false)
+	env := s.ankiddie.NewEnvWithCode("", s.hub.outFunc())
+
+	httpSrv := httptest.NewServer(s.Handler())
+	defer httpSrv.Close()
+
+	conn := dialStream(t, httpSrv, env.EID())
+	defer conn.Close()
+
+	if err := conn.WriteJSON(inboundFrame{Type: "execute", Code: "1 + 1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	conn.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+	var f frame
+	if err := conn.ReadJSON(&f); err == nil {
+		t.Errorf("got frame %+v from a user without PermMonkeyPatch, want the execute frame to be dropped", f)
+	}
+}
+
+func TestWebSocketExecuteFrameAllowedWithMonkeyPatchPermission(t *testing.T) {
+	s := newTestServer(fakeUser{perms: map[Permission]bool{PermRead: true, PermMonkeyPatch: true}}, false)
+	env := s.ankiddie.NewEnvWithCode("", s.hub.outFunc())
+
+	httpSrv := httptest.NewServer(s.Handler())
+	defer httpSrv.Close()
+
+	conn := dialStream(t, httpSrv, env.EID())
+	defer conn.Close()
+
+	if err := conn.WriteJSON(inboundFrame{Type: "execute", Code: "1 + 1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var f frame
+	if err := conn.ReadJSON(&f); err != nil {
+		t.Fatalf("ReadJSON: %v, want an output frame from a user with PermMonkeyPatch", err)
+	}
+	if f.Type != "output" || f.Output != "2" {
+		t.Errorf("frame = %+v, want Type=output Output=2", f)
+	}
+}
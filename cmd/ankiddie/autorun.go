@@ -0,0 +1,30 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/urfave/cli"
+)
+
+var autorunCommand = cli.Command{
+	Name:      "autorun",
+	Usage:     "run every stored script declared at the given autorun level",
+	ArgsUsage: "<level>",
+	Action:    autorunAction,
+}
+
+func autorunAction(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cli.NewExitError("usage: ankiddie autorun <level>", 1)
+	}
+	level, err := strconv.Atoi(c.Args().First())
+	if err != nil {
+		return cli.NewExitError("level must be an integer", 1)
+	}
+
+	ssys, err := ssysFromContext(c)
+	if err != nil {
+		return err
+	}
+	return ssys.StartAutorun(level, false, printOut)
+}
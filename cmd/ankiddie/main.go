@@ -0,0 +1,58 @@
+// Command ankiddie is a standalone operator CLI wrapping an *ankiddie.Ankiddie,
+// so consumers are not required to embed the library in their own binary just
+// to run or manage anko scripts.
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/urfave/cli"
+
+	"github.com/gbl08ma/ankiddie"
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "ankiddie"
+	app.Usage = "run and manage anko scripts"
+	app.Flags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "store",
+			Value: "file",
+			Usage: "script persistence backend: sqlite, bolt or file",
+		},
+		cli.StringFlag{
+			Name:  "store-path",
+			Value: "ankiddie.db",
+			Usage: "path to the persistence backend's database file or directory",
+		},
+	}
+	app.Commands = []cli.Command{
+		runCommand,
+		replCommand,
+		scriptCommand,
+		autorunCommand,
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// ssysFromContext builds an *ankiddie.Ankiddie backed by the persister selected
+// through the --store/--store-path global flags
+func ssysFromContext(c *cli.Context) (*ankiddie.Ankiddie, error) {
+	store, err := storeFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+	return ankiddie.New(nil, store), nil
+}
+
+// printOut is an ankiddie out callback that writes captured script output to stdout
+func printOut(env *ankiddie.Environment, msg string) error {
+	_, err := fmt.Print(msg)
+	return err
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/gbl08ma/ankiddie"
+)
+
+var replCommand = cli.Command{
+	Name:   "repl",
+	Usage:  "start an interactive line-oriented read-eval-print loop",
+	Action: replAction,
+}
+
+func replAction(c *cli.Context) error {
+	ssys, err := ssysFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	env := ssys.NewEnvWithCode("", printOut)
+	fmt.Println("ankiddie repl - type :help for meta-commands, :quit to exit")
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for {
+		fmt.Print("> ")
+		if !scanner.Scan() {
+			break
+		}
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, ":") {
+			if !handleMeta(ssys, &env, line) {
+				break
+			}
+			continue
+		}
+
+		result, err := env.Execute(line, false)
+		if err != nil {
+			fmt.Println("error:", err)
+			continue
+		}
+		if result != nil {
+			fmt.Printf("=> %#v\n", result)
+		}
+	}
+	return scanner.Err()
+}
+
+// handleMeta runs a ":"-prefixed meta-command against the REPL's current environment.
+// It returns false when the REPL should exit.
+func handleMeta(ssys *ankiddie.Ankiddie, env **ankiddie.Environment, line string) bool {
+	fields := strings.Fields(line)
+	switch fields[0] {
+	case ":help":
+		fmt.Println(":save <id>, :load <id>, :suspend, :restart, :vars, :monkey-list, :quit")
+
+	case ":save":
+		if len(fields) != 2 {
+			fmt.Println("usage: :save <id>")
+			return true
+		}
+		script, err := (*env).SaveScript(fields[1])
+		if err != nil {
+			fmt.Println("error:", err)
+			return true
+		}
+		fmt.Println("saved as", script.ID)
+
+	case ":load":
+		if len(fields) != 2 {
+			fmt.Println("usage: :load <id>")
+			return true
+		}
+		script, err := ssys.GetScript(fields[1])
+		if err != nil {
+			fmt.Println("error:", err)
+			return true
+		}
+		(*env).Forget()
+		*env = ssys.NewEnvWithScript(script, printOut)
+		fmt.Println("loaded", script.ID)
+
+	case ":suspend":
+		if err := (*env).Suspend(); err != nil {
+			fmt.Println("error:", err)
+		}
+
+	case ":restart":
+		if _, err := (*env).Restart(); err != nil {
+			fmt.Println("error:", err)
+		}
+
+	case ":vars":
+		fmt.Print((*env).Vars())
+
+	case ":monkey-list":
+		fmt.Println(ssys.ActivePatchCount(), "active monkey patch(es) in this process")
+
+	case ":quit", ":exit":
+		return false
+
+	default:
+		fmt.Println("unknown meta-command", fields[0])
+	}
+	return true
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"os"
+	"os/signal"
+
+	"github.com/urfave/cli"
+)
+
+var runCommand = cli.Command{
+	Name:      "run",
+	Usage:     "run a script file, streaming its output to stdout",
+	ArgsUsage: "<file>",
+	Action:    runAction,
+}
+
+func runAction(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cli.NewExitError("expected exactly one file argument", 1)
+	}
+
+	code, err := os.ReadFile(c.Args().First())
+	if err != nil {
+		return err
+	}
+
+	ssys, err := ssysFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	env := ssys.NewEnvWithCode(string(code), printOut)
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	go func() {
+		<-sigCh
+		env.Suspend()
+	}()
+
+	_, err = env.Start()
+	return err
+}
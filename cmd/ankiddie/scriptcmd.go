@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/urfave/cli"
+
+	"github.com/gbl08ma/ankiddie/persist"
+)
+
+var scriptCommand = cli.Command{
+	Name:  "script",
+	Usage: "manage stored scripts",
+	Subcommands: []cli.Command{
+		{Name: "list", Usage: "list every stored script", Action: scriptListAction},
+		{Name: "show", Usage: "print a stored script as JSON", ArgsUsage: "<id>", Action: scriptShowAction},
+		{Name: "save", Usage: "store a script from a file", ArgsUsage: "<id> <file>", Action: scriptSaveAction},
+		{Name: "delete", Usage: "delete a stored script", ArgsUsage: "<id>", Action: scriptDeleteAction},
+		{
+			Name:      "set-autorun",
+			Usage:     "change a stored script's autorun level",
+			ArgsUsage: "<id> <level>",
+			Action:    scriptSetAutorunAction,
+		},
+	},
+}
+
+func scriptListAction(c *cli.Context) error {
+	store, err := storeFromContext(c)
+	if err != nil {
+		return err
+	}
+	lister, ok := store.(persist.ScriptLister)
+	if !ok {
+		return cli.NewExitError("the selected store does not support listing scripts", 1)
+	}
+
+	scripts, err := lister.ListScripts()
+	if err != nil {
+		return err
+	}
+	for _, script := range scripts {
+		fmt.Printf("%s\tautorun=%d\n", script.ID, script.Autorun)
+	}
+	return nil
+}
+
+func scriptShowAction(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cli.NewExitError("usage: ankiddie script show <id>", 1)
+	}
+	store, err := storeFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	script, err := store.GetScript(c.Args().First())
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(script)
+}
+
+func scriptSaveAction(c *cli.Context) error {
+	if c.NArg() != 2 {
+		return cli.NewExitError("usage: ankiddie script save <id> <file>", 1)
+	}
+	code, err := os.ReadFile(c.Args().Get(1))
+	if err != nil {
+		return err
+	}
+
+	ssys, err := ssysFromContext(c)
+	if err != nil {
+		return err
+	}
+	script, err := ssys.SaveScript(c.Args().First(), string(code))
+	if err != nil {
+		return err
+	}
+	fmt.Println("saved as", script.ID)
+	return nil
+}
+
+func scriptDeleteAction(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cli.NewExitError("usage: ankiddie script delete <id>", 1)
+	}
+	store, err := storeFromContext(c)
+	if err != nil {
+		return err
+	}
+	deleter, ok := store.(persist.ScriptDeleter)
+	if !ok {
+		return cli.NewExitError("the selected store does not support deleting scripts", 1)
+	}
+	return deleter.DeleteScript(c.Args().First())
+}
+
+func scriptSetAutorunAction(c *cli.Context) error {
+	if c.NArg() != 2 {
+		return cli.NewExitError("usage: ankiddie script set-autorun <id> <level>", 1)
+	}
+	level, err := strconv.Atoi(c.Args().Get(1))
+	if err != nil {
+		return cli.NewExitError("level must be an integer", 1)
+	}
+
+	store, err := storeFromContext(c)
+	if err != nil {
+		return err
+	}
+	script, err := store.GetScript(c.Args().First())
+	if err != nil {
+		return err
+	}
+	script.Autorun = level
+	return store.StoreScript(script)
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/gbl08ma/ankiddie"
+	"github.com/gbl08ma/ankiddie/persist"
+)
+
+// storeFromContext constructs the ankiddie.ScriptPersister selected by the
+// --store and --store-path global flags
+func storeFromContext(c *cli.Context) (ankiddie.ScriptPersister, error) {
+	switch kind := c.GlobalString("store"); kind {
+	case "sqlite":
+		return persist.NewSQLiteStore(c.GlobalString("store-path"))
+	case "bolt":
+		return persist.NewBoltStore(c.GlobalString("store-path"))
+	case "file":
+		return persist.NewFileStore(c.GlobalString("store-path"))
+	default:
+		return nil, fmt.Errorf("unknown store backend %q", kind)
+	}
+}
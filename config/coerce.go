@@ -0,0 +1,101 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+func coerceString(d ParamDecl, value interface{}) (interface{}, error) {
+	s, ok := value.(string)
+	if !ok {
+		return nil, &InvalidDeclError{Name: d.Name, Reason: fmt.Sprintf("expected string, got %T", value)}
+	}
+	if len(d.Enum) > 0 {
+		for _, allowed := range d.Enum {
+			if s == allowed {
+				return s, nil
+			}
+		}
+		return nil, &InvalidDeclError{Name: d.Name, Reason: fmt.Sprintf("%q is not one of %v", s, d.Enum)}
+	}
+	return s, nil
+}
+
+func coerceInt(d ParamDecl, value interface{}) (interface{}, error) {
+	var i int
+	switch v := value.(type) {
+	case int:
+		i = v
+	case int64:
+		i = int(v)
+	case float64:
+		i = int(v)
+	default:
+		return nil, &InvalidDeclError{Name: d.Name, Reason: fmt.Sprintf("expected int, got %T", value)}
+	}
+	if err := d.checkRange(d.Name, float64(i)); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+func coerceBool(value interface{}) (interface{}, error) {
+	b, ok := value.(bool)
+	if !ok {
+		return nil, &InvalidDeclError{Reason: fmt.Sprintf("expected bool, got %T", value)}
+	}
+	return b, nil
+}
+
+func coerceFloat(d ParamDecl, value interface{}) (interface{}, error) {
+	var f float64
+	switch v := value.(type) {
+	case float64:
+		f = v
+	case float32:
+		f = float64(v)
+	case int:
+		f = float64(v)
+	default:
+		return nil, &InvalidDeclError{Name: d.Name, Reason: fmt.Sprintf("expected float, got %T", value)}
+	}
+	if err := d.checkRange(d.Name, f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func coerceDuration(d ParamDecl, value interface{}) (interface{}, error) {
+	var dur time.Duration
+	switch v := value.(type) {
+	case time.Duration:
+		dur = v
+	case string:
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, &InvalidDeclError{Name: d.Name, Reason: err.Error()}
+		}
+		dur = parsed
+	case int64:
+		dur = time.Duration(v)
+	case float64:
+		// a time.Duration persisted as JSON is read back as a number of nanoseconds
+		dur = time.Duration(v)
+	default:
+		return nil, &InvalidDeclError{Name: d.Name, Reason: fmt.Sprintf("expected duration, got %T", value)}
+	}
+	if err := d.checkRange(d.Name, dur.Seconds()); err != nil {
+		return nil, err
+	}
+	return dur, nil
+}
+
+func (d ParamDecl) checkRange(name string, v float64) error {
+	if d.Min != nil && v < *d.Min {
+		return &InvalidDeclError{Name: name, Reason: fmt.Sprintf("%v is below minimum %v", v, *d.Min)}
+	}
+	if d.Max != nil && v > *d.Max {
+		return &InvalidDeclError{Name: name, Reason: fmt.Sprintf("%v is above maximum %v", v, *d.Max)}
+	}
+	return nil
+}
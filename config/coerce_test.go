@@ -0,0 +1,70 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func float64p(v float64) *float64 { return &v }
+
+func TestCoerceIntRange(t *testing.T) {
+	d := ParamDecl{Name: "n", Type: TypeInt, Min: float64p(0), Max: float64p(10)}
+
+	if _, err := d.coerce(5); err != nil {
+		t.Errorf("coerce(5) err = %v, want nil", err)
+	}
+	if _, err := d.coerce(11); err == nil {
+		t.Error("coerce(11) err = nil, want out-of-range error")
+	}
+}
+
+func TestCoerceFloatRange(t *testing.T) {
+	d := ParamDecl{Name: "f", Type: TypeFloat, Min: float64p(0.5), Max: float64p(1.5)}
+
+	if _, err := d.coerce(1.0); err != nil {
+		t.Errorf("coerce(1.0) err = %v, want nil", err)
+	}
+	if _, err := d.coerce(0.1); err == nil {
+		t.Error("coerce(0.1) err = nil, want below-minimum error")
+	}
+}
+
+func TestCoerceDurationRange(t *testing.T) {
+	d := ParamDecl{Name: "timeout", Type: TypeDuration, Min: float64p(5), Max: float64p(10)}
+
+	got, err := d.coerce("7s")
+	if err != nil {
+		t.Fatalf("coerce(7s) err = %v, want nil", err)
+	}
+	if got != 7*time.Second {
+		t.Errorf("coerce(7s) = %v, want 7s", got)
+	}
+
+	if _, err := d.coerce("999999s"); err == nil {
+		t.Error("coerce(999999s) err = nil, want above-maximum error")
+	}
+	if _, err := d.coerce("1s"); err == nil {
+		t.Error("coerce(1s) err = nil, want below-minimum error")
+	}
+}
+
+func TestCoerceDurationInvalid(t *testing.T) {
+	d := ParamDecl{Name: "timeout", Type: TypeDuration}
+	if _, err := d.coerce("not-a-duration"); err == nil {
+		t.Error("coerce(not-a-duration) err = nil, want parse error")
+	}
+	if _, err := d.coerce(true); err == nil {
+		t.Error("coerce(true) err = nil, want type error")
+	}
+}
+
+func TestCoerceStringEnum(t *testing.T) {
+	d := ParamDecl{Name: "mode", Type: TypeString, Enum: []string{"fast", "slow"}}
+
+	if _, err := d.coerce("fast"); err != nil {
+		t.Errorf("coerce(fast) err = %v, want nil", err)
+	}
+	if _, err := d.coerce("medium"); err == nil {
+		t.Error("coerce(medium) err = nil, want not-in-enum error")
+	}
+}
@@ -0,0 +1,76 @@
+// Package config lets anko scripts declare typed parameters that operators can
+// tweak without editing source, turning opaque code blobs into configurable policies.
+package config
+
+import "time"
+
+// ParamType identifies the accepted type of a ParamDecl
+type ParamType string
+
+// Supported parameter types
+const (
+	TypeString   ParamType = "string"
+	TypeInt      ParamType = "int"
+	TypeBool     ParamType = "bool"
+	TypeFloat    ParamType = "float"
+	TypeDuration ParamType = "duration"
+)
+
+// ParamDecl declares a single typed, operator-tunable parameter of a script
+type ParamDecl struct {
+	Name        string
+	Type        ParamType
+	Default     interface{}
+	Description string
+	// Min and Max bound int, float and duration values; both nil means unbounded.
+	// For TypeDuration, Min/Max are compared against the duration's value in seconds.
+	Min *float64
+	Max *float64
+	// Enum restricts a string parameter to one of the given values; empty means unrestricted
+	Enum []string
+}
+
+func (d ParamDecl) coerce(value interface{}) (interface{}, error) {
+	switch d.Type {
+	case TypeString:
+		return coerceString(d, value)
+	case TypeInt:
+		return coerceInt(d, value)
+	case TypeBool:
+		return coerceBool(value)
+	case TypeFloat:
+		return coerceFloat(d, value)
+	case TypeDuration:
+		return coerceDuration(d, value)
+	default:
+		return nil, &InvalidDeclError{Name: d.Name, Reason: "unknown type " + string(d.Type)}
+	}
+}
+
+// InvalidDeclError reports a ParamDecl that cannot describe the value it was given
+type InvalidDeclError struct {
+	Name   string
+	Reason string
+}
+
+func (e *InvalidDeclError) Error() string {
+	return "param " + e.Name + ": " + e.Reason
+}
+
+// zeroValue returns the Go zero value matching d.Type, used when no default is set
+func (d ParamDecl) zeroValue() interface{} {
+	switch d.Type {
+	case TypeString:
+		return ""
+	case TypeInt:
+		return 0
+	case TypeBool:
+		return false
+	case TypeFloat:
+		return 0.0
+	case TypeDuration:
+		return time.Duration(0)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,44 @@
+package config
+
+import "encoding/json"
+
+// fieldSchema is the JSON Schema fragment describing a single ParamDecl
+type fieldSchema struct {
+	Type        string      `json:"type"`
+	Description string      `json:"description,omitempty"`
+	Default     interface{} `json:"default,omitempty"`
+	Minimum     *float64    `json:"minimum,omitempty"`
+	Maximum     *float64    `json:"maximum,omitempty"`
+	Enum        []string    `json:"enum,omitempty"`
+}
+
+// objectSchema is a minimal JSON Schema "object" document, enough for a UI or the
+// HTTP API to render a form from a script's declared parameters
+type objectSchema struct {
+	Type       string                 `json:"type"`
+	Properties map[string]fieldSchema `json:"properties"`
+}
+
+var jsonSchemaTypes = map[ParamType]string{
+	TypeString:   "string",
+	TypeInt:      "integer",
+	TypeBool:     "boolean",
+	TypeFloat:    "number",
+	TypeDuration: "string",
+}
+
+// Schema renders decls as a JSON Schema object document
+func Schema(decls []ParamDecl) ([]byte, error) {
+	obj := objectSchema{Type: "object", Properties: make(map[string]fieldSchema, len(decls))}
+	for _, d := range decls {
+		obj.Properties[d.Name] = fieldSchema{
+			Type:        jsonSchemaTypes[d.Type],
+			Description: d.Description,
+			Default:     d.Default,
+			Minimum:     d.Min,
+			Maximum:     d.Max,
+			Enum:        d.Enum,
+		}
+	}
+	return json.MarshalIndent(obj, "", "  ")
+}
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ParamPersister loads and stores the parameter values associated with a script.
+// ankiddie.ScriptPersister implementations satisfy this interface.
+type ParamPersister interface {
+	LoadParams(scriptID string) (map[string]interface{}, error)
+	StoreParams(scriptID string, values map[string]interface{}) error
+}
+
+// ParamStore holds the live values of a script's declared parameters, persisting
+// changes through a ParamPersister and notifying subscribers on every update
+type ParamStore struct {
+	mu        sync.Mutex
+	persister ParamPersister
+	scriptID  string
+	decls     map[string]ParamDecl
+	values    map[string]interface{}
+	subs      map[string][]chan interface{}
+}
+
+// NewStore returns a ParamStore for the parameters declared in decls, initialized from
+// whatever values persister has for scriptID, falling back to each ParamDecl's Default
+func NewStore(persister ParamPersister, scriptID string, decls []ParamDecl) (*ParamStore, error) {
+	s := &ParamStore{
+		persister: persister,
+		scriptID:  scriptID,
+		decls:     make(map[string]ParamDecl, len(decls)),
+		values:    make(map[string]interface{}, len(decls)),
+		subs:      make(map[string][]chan interface{}),
+	}
+
+	persisted := make(map[string]interface{})
+	if persister != nil {
+		var err error
+		persisted, err = persister.LoadParams(scriptID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, d := range decls {
+		s.decls[d.Name] = d
+		if v, ok := persisted[d.Name]; ok {
+			coerced, err := d.coerce(v)
+			if err != nil {
+				return nil, err
+			}
+			s.values[d.Name] = coerced
+		} else if d.Default != nil {
+			coerced, err := d.coerce(d.Default)
+			if err != nil {
+				return nil, err
+			}
+			s.values[d.Name] = coerced
+		} else {
+			s.values[d.Name] = d.zeroValue()
+		}
+	}
+
+	return s, nil
+}
+
+// Get returns the current value of a declared parameter
+func (s *ParamStore) Get(name string) (interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.values[name]
+	return v, ok
+}
+
+// Set validates value against the parameter's declaration, stores it, persists it
+// through the backing ParamPersister, and notifies every Subscribe-r of the new value
+func (s *ParamStore) Set(name string, value interface{}) error {
+	s.mu.Lock()
+	d, ok := s.decls[name]
+	if !ok {
+		s.mu.Unlock()
+		return &InvalidDeclError{Name: name, Reason: "not declared"}
+	}
+	coerced, err := d.coerce(value)
+	if err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	s.values[name] = coerced
+
+	snapshot := make(map[string]interface{}, len(s.values))
+	for k, v := range s.values {
+		snapshot[k] = v
+	}
+	subs := append([]chan interface{}{}, s.subs[name]...)
+	s.mu.Unlock()
+
+	if s.persister != nil {
+		if err := s.persister.StoreParams(s.scriptID, snapshot); err != nil {
+			return fmt.Errorf("param %s updated but not persisted: %w", name, err)
+		}
+	}
+
+	for _, ch := range subs {
+		select {
+		case ch <- coerced:
+		default:
+		}
+	}
+	return nil
+}
+
+// Subscribe returns a channel that receives the values name is Set to, and a function
+// that unsubscribes and closes the channel. The channel buffers 4 values; a value Set
+// while the buffer is full is dropped for this channel. Callers must call the returned function once done.
+func (s *ParamStore) Subscribe(name string) (<-chan interface{}, func()) {
+	ch := make(chan interface{}, 4)
+	s.mu.Lock()
+	s.subs[name] = append(s.subs[name], ch)
+	s.mu.Unlock()
+
+	return ch, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		subs := s.subs[name]
+		for i, c := range subs {
+			if c == ch {
+				s.subs[name] = append(subs[:i], subs[i+1:]...)
+				close(ch)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package config
+
+import "testing"
+
+// fakePersister is an in-memory ParamPersister for tests
+type fakePersister struct {
+	values map[string]map[string]interface{}
+}
+
+func newFakePersister() *fakePersister {
+	return &fakePersister{values: make(map[string]map[string]interface{})}
+}
+
+func (f *fakePersister) LoadParams(scriptID string) (map[string]interface{}, error) {
+	return f.values[scriptID], nil
+}
+
+func (f *fakePersister) StoreParams(scriptID string, values map[string]interface{}) error {
+	f.values[scriptID] = values
+	return nil
+}
+
+func TestNewStorePrefersPersistedOverDefault(t *testing.T) {
+	persister := newFakePersister()
+	persister.values["s1"] = map[string]interface{}{"timeout": "20s"}
+
+	decls := []ParamDecl{
+		{Name: "timeout", Type: TypeDuration, Default: "10s"},
+		{Name: "mode", Type: TypeString, Default: "fast"},
+	}
+
+	s, err := NewStore(persister, "s1", decls)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	timeout, ok := s.Get("timeout")
+	if !ok {
+		t.Fatal("Get(timeout) ok = false")
+	}
+	if timeout.(interface{ Seconds() float64 }).Seconds() != 20 {
+		t.Errorf("timeout = %v, want 20s (the persisted value, not the 10s default)", timeout)
+	}
+
+	mode, ok := s.Get("mode")
+	if !ok || mode != "fast" {
+		t.Errorf("mode = %v, want the default %q since nothing was persisted for it", mode, "fast")
+	}
+}
+
+func TestNewStoreFallsBackToZeroValue(t *testing.T) {
+	decls := []ParamDecl{{Name: "n", Type: TypeInt}}
+
+	s, err := NewStore(nil, "s1", decls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, ok := s.Get("n")
+	if !ok || n != 0 {
+		t.Errorf("n = %v, want the zero value 0", n)
+	}
+}
+
+func TestParamStoreSetNotifiesSubscribers(t *testing.T) {
+	decls := []ParamDecl{{Name: "n", Type: TypeInt}}
+	s, err := NewStore(nil, "s1", decls)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ch, unsubscribe := s.Subscribe("n")
+	defer unsubscribe()
+
+	if err := s.Set("n", 5); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case v := <-ch:
+		if v != 5 {
+			t.Errorf("notified value = %v, want 5", v)
+		}
+	default:
+		t.Error("Subscribe channel received nothing after Set")
+	}
+
+	got, _ := s.Get("n")
+	if got != 5 {
+		t.Errorf("Get(n) after Set = %v, want 5", got)
+	}
+}
+
+func TestParamStoreSetRejectsUndeclared(t *testing.T) {
+	s, err := NewStore(nil, "s1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set("missing", 1); err == nil {
+		t.Error("Set(missing) err = nil, want an error for an undeclared param")
+	}
+}
+
+func TestParamStoreSetPersists(t *testing.T) {
+	persister := newFakePersister()
+	decls := []ParamDecl{{Name: "n", Type: TypeInt}}
+	s, err := NewStore(persister, "s1", decls)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Set("n", 7); err != nil {
+		t.Fatal(err)
+	}
+	if persister.values["s1"]["n"] != 7 {
+		t.Errorf("persisted value = %v, want 7", persister.values["s1"]["n"])
+	}
+}
+
+func TestParamStoreUnsubscribeClosesChannel(t *testing.T) {
+	decls := []ParamDecl{{Name: "n", Type: TypeInt}}
+	s, err := NewStore(nil, "s1", decls)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ch, unsubscribe := s.Subscribe("n")
+	unsubscribe()
+
+	if _, open := <-ch; open {
+		t.Error("channel still open after unsubscribe")
+	}
+
+	// Set must not panic or block now that the subscriber is gone
+	if err := s.Set("n", 1); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -9,6 +9,8 @@ import (
 
 	"github.com/gbl08ma/monkey"
 
+	"github.com/gbl08ma/ankiddie/config"
+	"github.com/gbl08ma/ankiddie/notify"
 	"github.com/gbl08ma/anko/core"
 	"github.com/gbl08ma/anko/env"
 	"github.com/gbl08ma/anko/vm"
@@ -20,18 +22,30 @@ var ErrAlreadySuspended = errors.New("environment already suspended")
 // ErrAlreadyStarted when an environment had already been started
 var ErrAlreadyStarted = errors.New("environment already started")
 
+// ErrNotStarted when an operation requires an environment to have been started at least once
+var ErrNotStarted = errors.New("environment not started")
+
+// ErrNoParams when an environment has no ParamStore associated, because its
+// script declared no Params
+var ErrNoParams = errors.New("environment has no declared parameters")
+
 // Environment is a anko environment managed by Ankiddie
 type Environment struct {
-	ssys      *Ankiddie
-	eid       uint
-	ankoenv   *env.Env
-	ctx       context.Context
-	cancel    context.CancelFunc
-	started   bool
-	suspended bool
-	src       string
-	srcDirty  bool
-	scriptID  string
+	ssys         *Ankiddie
+	eid          uint
+	ankoenv      *env.Env
+	ctx          context.Context
+	cancel       context.CancelFunc
+	started      bool
+	suspended    bool
+	src          string
+	srcDirty     bool
+	scriptID     string
+	listeners    []chan<- Event
+	params       *config.ParamStore
+	paramUnsubs  []func()
+	notifyPolicy *notify.Policy
+	outTail      *outTailBuffer
 }
 
 func (ssys *Ankiddie) newEnv(eid uint, code string, out func(env *Environment, msg string) error) *Environment {
@@ -41,6 +55,7 @@ func (ssys *Ankiddie) newEnv(eid uint, code string, out func(env *Environment, m
 		src:       code,
 		suspended: true,
 		ankoenv:   env.NewEnv(),
+		outTail:   &outTailBuffer{},
 	}
 	core.Import(env.ankoenv)
 
@@ -50,16 +65,19 @@ func (ssys *Ankiddie) newEnv(eid uint, code string, out func(env *Environment, m
 
 	env.ankoenv.Define("println", func(a ...interface{}) (n int, err error) {
 		msg := fmt.Sprintln(a...)
+		env.outTail.Write(msg)
 		return len(msg), out(env, msg)
 	})
 
 	env.ankoenv.Define("print", func(a ...interface{}) (n int, err error) {
 		msg := fmt.Sprint(a...)
+		env.outTail.Write(msg)
 		return len(msg), out(env, msg)
 	})
 
 	env.ankoenv.Define("printf", func(format string, a ...interface{}) (n int, err error) {
 		msg := fmt.Sprintf(format, a...)
+		env.outTail.Write(msg)
 		return len(msg), out(env, msg)
 	})
 
@@ -71,6 +89,27 @@ func (ssys *Ankiddie) newEnv(eid uint, code string, out func(env *Environment, m
 		return vp.Interface()
 	})
 	env.ankoenv.Define("error", reflect.Zero(reflect.TypeOf((*error)(nil)).Elem()).Interface())
+	env.ankoenv.Define("param", func(name string) interface{} {
+		if env.params == nil {
+			return nil
+		}
+		v, _ := env.params.Get(name)
+		return v
+	})
+	env.ankoenv.Define("param_changed", func(name string, fn func(interface{})) {
+		if env.params == nil {
+			return
+		}
+		ch, unsubscribe := env.params.Subscribe(name)
+		env.ssys.m.Lock()
+		env.paramUnsubs = append(env.paramUnsubs, unsubscribe)
+		env.ssys.m.Unlock()
+		go func() {
+			for v := range ch {
+				fn(v)
+			}
+		}()
+	})
 	// TODO inspect might not be really needed, as core.Import already defines typeOf
 	env.ankoenv.Define("inspect", func(obj interface{}) string {
 		t := reflect.TypeOf(obj)
@@ -127,6 +166,13 @@ func (ssys *Ankiddie) newEnv(eid uint, code string, out func(env *Environment, m
 	return env
 }
 
+// ActivePatchCount returns the number of monkey patches currently active in the host
+// process. Monkey patching is process-global (see the TODO in New),
+// so this is not scoped to any one Environment or Ankiddie instance.
+func (ssys *Ankiddie) ActivePatchCount() int {
+	return monkey.PatchCount()
+}
+
 func (env *Environment) makeStrengthenFunction() func(fn interface{}, argsForTypes ...interface{}) interface{} {
 	return func(fn interface{}, argsForTypes ...interface{}) interface{} {
 		env.ssys.m.Lock()
@@ -146,8 +192,14 @@ func (env *Environment) Start() (interface{}, error) {
 	env.started = true
 	env.suspended = false
 	env.ctx, env.cancel = context.WithCancel(context.Background())
+	env.emit(EventStarted)
+	env.notify(notify.Started, notify.SeverityInfo, "")
 	env.ssys.m.Unlock()
-	return vm.ExecuteContext(env.ctx, env.ankoenv, nil, env.src)
+	result, err := vm.ExecuteContext(env.ctx, env.ankoenv, nil, env.src)
+	if err != nil {
+		env.notify(notify.Errored, notify.SeverityError, err.Error())
+	}
+	return result, err
 }
 
 // Suspend stops the execution on the environment without destroying its state
@@ -161,18 +213,30 @@ func (env *Environment) Suspend() error {
 
 	env.cancel()
 	env.suspended = true
+	env.emit(EventSuspended)
+	env.notify(notify.Suspended, notify.SeverityInfo, "")
 	return nil
 }
 
-// Restart restarts the execution on the environment
+// Restart restarts the execution on the environment. The environment must have been
+// started at least once before, since there is otherwise no prior run to cancel.
 func (env *Environment) Restart() (interface{}, error) {
 	env.ssys.m.Lock()
+	if !env.started {
+		env.ssys.m.Unlock()
+		return nil, ErrNotStarted
+	}
 	env.cancel()
 	env.suspended = false
-	env.started = true
 	env.ctx, env.cancel = context.WithCancel(context.Background())
+	env.emit(EventRestarted)
+	env.notify(notify.Restarted, notify.SeverityInfo, "")
 	env.ssys.m.Unlock()
-	return vm.ExecuteContext(env.ctx, env.ankoenv, nil, env.src)
+	result, err := vm.ExecuteContext(env.ctx, env.ankoenv, nil, env.src)
+	if err != nil {
+		env.notify(notify.Errored, notify.SeverityError, err.Error())
+	}
+	return result, err
 }
 
 // Execute parses and runs source in current scope
@@ -188,7 +252,11 @@ func (env *Environment) Execute(source string, appendToSrc bool) (interface{}, e
 	env.started = true
 	env.suspended = false
 	env.ssys.m.Unlock()
-	return vm.ExecuteContext(env.ctx, env.ankoenv, nil, source)
+	result, err := vm.ExecuteContext(env.ctx, env.ankoenv, nil, source)
+	if err != nil {
+		env.notify(notify.Errored, notify.SeverityError, err.Error())
+	}
+	return result, err
 }
 
 // Forget stops execution of the given environment as far as possible and unregisters it
@@ -240,3 +308,23 @@ func (env *Environment) Started() bool {
 func (env *Environment) Suspended() bool {
 	return env.suspended
 }
+
+// Get returns the value of a variable defined in the environment's scope
+func (env *Environment) Get(name string) (interface{}, error) {
+	return env.ankoenv.Get(name)
+}
+
+// Vars returns a human-readable dump of the variables and types currently defined
+// in the environment's scope
+func (env *Environment) Vars() string {
+	return env.ankoenv.String()
+}
+
+// SetParam validates and updates the value of a declared parameter, persisting it and
+// notifying any param_changed subscribers running inside the script
+func (env *Environment) SetParam(name string, value interface{}) error {
+	if env.params == nil {
+		return ErrNoParams
+	}
+	return env.params.Set(name, value)
+}
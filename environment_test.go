@@ -0,0 +1,29 @@
+package ankiddie
+
+import "testing"
+
+func TestRestartRequiresStart(t *testing.T) {
+	ssys := New(nil, nil)
+	env := ssys.NewEnvWithCode("1 + 1", nil)
+
+	if _, err := env.Restart(); err != ErrNotStarted {
+		t.Fatalf("Restart() on a never-started environment err = %v, want ErrNotStarted", err)
+	}
+
+	// a failed guard must not leave ssys.m locked behind it
+	if _, ok := ssys.Environment(env.EID()); !ok {
+		t.Fatal("Environment lookup hung or failed after a guarded Restart, ssys.m may still be locked")
+	}
+}
+
+func TestRestartAfterStart(t *testing.T) {
+	ssys := New(nil, nil)
+	env := ssys.NewEnvWithCode("1 + 1", nil)
+
+	if _, err := env.Start(); err != nil {
+		t.Fatalf("Start() err = %v, want nil", err)
+	}
+	if _, err := env.Restart(); err != nil {
+		t.Fatalf("Restart() after Start() err = %v, want nil", err)
+	}
+}
@@ -0,0 +1,49 @@
+package ankiddie
+
+// EventType identifies the kind of lifecycle transition an Environment went through
+type EventType int
+
+// Lifecycle event types emitted by an Environment
+const (
+	EventStarted EventType = iota
+	EventSuspended
+	EventRestarted
+	EventForgotten
+)
+
+// Event describes a lifecycle transition emitted by an Environment
+type Event struct {
+	EID  uint
+	Type EventType
+}
+
+// Subscribe registers ch to receive lifecycle events emitted by this environment.
+// The returned function unsubscribes ch; callers must eventually call it to avoid leaking the channel.
+// Events are dropped, not blocked on, if ch is not ready to receive.
+func (env *Environment) Subscribe(ch chan<- Event) func() {
+	env.ssys.m.Lock()
+	defer env.ssys.m.Unlock()
+	env.listeners = append(env.listeners, ch)
+	return func() {
+		env.ssys.m.Lock()
+		defer env.ssys.m.Unlock()
+		for i, l := range env.listeners {
+			if l == ch {
+				env.listeners = append(env.listeners[:i], env.listeners[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// emit notifies all subscribers of this environment of the given lifecycle transition.
+// Callers must hold ssys.m.
+func (env *Environment) emit(t EventType) {
+	ev := Event{EID: env.eid, Type: t}
+	for _, l := range env.listeners {
+		select {
+		case l <- ev:
+		default:
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package ankiddie
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gbl08ma/ankiddie/notify"
+)
+
+// notify dispatches a notify.Event to ssys.notifier, if one is configured and policy
+// allows it. Dispatch runs on its own goroutine so a slow sink cannot block script execution.
+func (ssys *Ankiddie) notify(eid uint, scriptID string, t notify.EventType, sev notify.Severity, msg string, outTail string, policy *notify.Policy) {
+	if !policy.Allows(t, sev) {
+		return
+	}
+	ssys.notifierMu.RLock()
+	n := ssys.notifier
+	ssys.notifierMu.RUnlock()
+	if n == nil {
+		return
+	}
+	ev := notify.Event{EID: eid, ScriptID: scriptID, Type: t, Severity: sev, Message: msg, OutTail: outTail}
+	go n.Notify(context.Background(), ev)
+}
+
+// notify dispatches a notify.Event for this environment's lifecycle or errors,
+// through the owning Ankiddie's configured Notifier. OutTail is populated from the
+// environment's captured println/print/printf output.
+func (env *Environment) notify(t notify.EventType, sev notify.Severity, msg string) {
+	env.ssys.notify(env.eid, env.scriptID, t, sev, msg, env.outTail.String(), env.notifyPolicy)
+}
+
+// startForAutorun runs Start and turns any error or panic it produces into a notify
+// event, since the caller in StartAutorun may be running this on its own goroutine
+// and would otherwise have no way to observe the outcome
+func (env *Environment) startForAutorun() {
+	defer func() {
+		if r := recover(); r != nil {
+			env.notify(notify.Panicked, notify.SeverityCritical, fmt.Sprint(r))
+		}
+	}()
+	if _, err := env.Start(); err != nil {
+		env.notify(notify.Errored, notify.SeverityError, err.Error())
+	}
+}
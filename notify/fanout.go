@@ -0,0 +1,34 @@
+package notify
+
+import (
+	"context"
+	"strings"
+)
+
+// FanOut dispatches every Event to all of its sinks
+type FanOut []Notifier
+
+// multiError joins the errors returned by a FanOut's sinks into a single error
+type multiError []error
+
+func (m multiError) Error() string {
+	msgs := make([]string, len(m))
+	for i, err := range m {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Notify implements Notifier, calling every sink and joining any errors they return
+func (f FanOut) Notify(ctx context.Context, event Event) error {
+	var errs multiError
+	for _, sink := range f {
+		if err := sink.Notify(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
@@ -0,0 +1,25 @@
+package notify
+
+import (
+	"context"
+	"log"
+)
+
+// LogNotifier writes events to a standard library Logger
+type LogNotifier struct {
+	logger *log.Logger
+}
+
+// NewLogNotifier returns a LogNotifier writing to logger. If logger is nil, log.Default() is used.
+func NewLogNotifier(logger *log.Logger) *LogNotifier {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &LogNotifier{logger: logger}
+}
+
+// Notify implements Notifier
+func (n *LogNotifier) Notify(ctx context.Context, event Event) error {
+	n.logger.Printf("[env %d][script %s][%s] %s", event.EID, event.ScriptID, event.Type, event.Message)
+	return nil
+}
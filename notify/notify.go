@@ -0,0 +1,48 @@
+// Package notify emits structured events for environment lifecycle transitions and
+// script errors, dispatching them to pluggable sinks such as a log or an outbound webhook.
+package notify
+
+import "context"
+
+// Severity classifies how urgently an Event should be acted upon
+type Severity int
+
+// Supported severities, in increasing order of urgency
+const (
+	SeverityDebug Severity = iota
+	SeverityInfo
+	SeverityWarning
+	SeverityError
+	SeverityCritical
+)
+
+// EventType identifies the kind of occurrence an Event reports
+type EventType string
+
+// Event types emitted by an Environment or by Ankiddie.StartAutorun
+const (
+	Started         EventType = "started"
+	Suspended       EventType = "suspended"
+	Restarted       EventType = "restarted"
+	Forgotten       EventType = "forgotten"
+	Panicked        EventType = "panicked"
+	Errored         EventType = "errored"
+	AutorunFinished EventType = "autorun_finished"
+)
+
+// Event describes a single lifecycle transition or error, ready to hand to a Notifier
+type Event struct {
+	EID      uint
+	ScriptID string
+	Type     EventType
+	Severity Severity
+	// Message carries the error text for Panicked/Errored events
+	Message string
+	// OutTail carries the last lines of captured println/print/printf output, if available
+	OutTail string
+}
+
+// Notifier dispatches Events to some sink. Implementations must be safe for concurrent use.
+type Notifier interface {
+	Notify(ctx context.Context, event Event) error
+}
@@ -0,0 +1,26 @@
+package notify
+
+// Policy controls which Events a script's Environment is allowed to raise. An
+// Environment takes it from its script's Script.NotifyPolicy field; Script.Notes is not parsed for one.
+type Policy struct {
+	// MinSeverity suppresses events below this severity. Zero value (SeverityDebug) suppresses nothing.
+	MinSeverity Severity
+	// Suppress lists event types that are never raised, regardless of severity
+	Suppress []EventType
+}
+
+// Allows reports whether an event of the given type and severity should be raised
+func (p *Policy) Allows(t EventType, sev Severity) bool {
+	if p == nil {
+		return true
+	}
+	if sev < p.MinSeverity {
+		return false
+	}
+	for _, s := range p.Suppress {
+		if s == t {
+			return false
+		}
+	}
+	return true
+}
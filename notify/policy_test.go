@@ -0,0 +1,30 @@
+package notify
+
+import "testing"
+
+func TestPolicyAllowsNil(t *testing.T) {
+	var p *Policy
+	if !p.Allows(Started, SeverityDebug) {
+		t.Error("nil Policy should allow everything")
+	}
+}
+
+func TestPolicyMinSeverity(t *testing.T) {
+	p := &Policy{MinSeverity: SeverityWarning}
+	if p.Allows(Started, SeverityInfo) {
+		t.Error("Allows(Info) = true, want false below MinSeverity")
+	}
+	if !p.Allows(Errored, SeverityError) {
+		t.Error("Allows(Error) = false, want true at or above MinSeverity")
+	}
+}
+
+func TestPolicySuppress(t *testing.T) {
+	p := &Policy{Suppress: []EventType{Suspended}}
+	if p.Allows(Suspended, SeverityCritical) {
+		t.Error("Allows(Suspended) = true, want false when suppressed")
+	}
+	if !p.Allows(Started, SeverityCritical) {
+		t.Error("Allows(Started) = false, want true when not suppressed")
+	}
+}
@@ -0,0 +1,110 @@
+package notify
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter wraps a Notifier, dropping events for a given (EID, Type) pair once
+// more than Burst of them have been seen within Window, so a crash-looping script
+// cannot spam the wrapped sinks
+type RateLimiter struct {
+	next   Notifier
+	window time.Duration
+	burst  int
+
+	mu     sync.Mutex
+	counts map[rateKey][]time.Time
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+type rateKey struct {
+	eid uint
+	typ EventType
+}
+
+// NewRateLimiter returns a RateLimiter that allows at most burst occurrences of the
+// same (EID, Type) pair per window, forwarding those to next and dropping the rest. It runs a background
+// goroutine that periodically evicts keys that have gone quiet, so a long-running process
+// does not accumulate one map entry per (EID, Type) pair forever as environments come and
+// go; call Close to stop it once the RateLimiter is no longer needed.
+func NewRateLimiter(next Notifier, window time.Duration, burst int) *RateLimiter {
+	r := &RateLimiter{
+		next:   next,
+		window: window,
+		burst:  burst,
+		counts: make(map[rateKey][]time.Time),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go r.sweep()
+	return r
+}
+
+// Notify implements Notifier
+func (r *RateLimiter) Notify(ctx context.Context, event Event) error {
+	key := rateKey{eid: event.EID, typ: event.Type}
+	now := time.Now()
+
+	r.mu.Lock()
+	seen := r.counts[key]
+	cutoff := now.Add(-r.window)
+	fresh := seen[:0]
+	for _, t := range seen {
+		if t.After(cutoff) {
+			fresh = append(fresh, t)
+		}
+	}
+	allowed := len(fresh) < r.burst
+	if allowed {
+		fresh = append(fresh, now)
+	}
+	if len(fresh) == 0 {
+		delete(r.counts, key)
+	} else {
+		r.counts[key] = fresh
+	}
+	r.mu.Unlock()
+
+	if !allowed {
+		return nil
+	}
+	return r.next.Notify(ctx, event)
+}
+
+// sweep periodically evicts keys whose most recent event fell outside window, so keys
+// belonging to forgotten environments don't linger in the map forever once Notify stops
+// being called for them.
+func (r *RateLimiter) sweep() {
+	defer close(r.done)
+	ticker := time.NewTicker(r.window)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			r.evictStale()
+		case <-r.stop:
+			return
+		}
+	}
+}
+
+func (r *RateLimiter) evictStale() {
+	cutoff := time.Now().Add(-r.window)
+	r.mu.Lock()
+	for key, seen := range r.counts {
+		if len(seen) == 0 || seen[len(seen)-1].Before(cutoff) {
+			delete(r.counts, key)
+		}
+	}
+	r.mu.Unlock()
+}
+
+// Close stops the RateLimiter's background eviction goroutine
+func (r *RateLimiter) Close() {
+	close(r.stop)
+	<-r.done
+}
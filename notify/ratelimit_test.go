@@ -0,0 +1,78 @@
+package notify
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type countingNotifier struct {
+	calls int
+}
+
+func (c *countingNotifier) Notify(ctx context.Context, event Event) error {
+	c.calls++
+	return nil
+}
+
+func TestRateLimiterAllowsUpToBurst(t *testing.T) {
+	next := &countingNotifier{}
+	r := NewRateLimiter(next, time.Minute, 2)
+	defer r.Close()
+
+	ev := Event{EID: 1, Type: Started}
+	for i := 0; i < 2; i++ {
+		if err := r.Notify(context.Background(), ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if next.calls != 2 {
+		t.Errorf("calls = %d, want 2", next.calls)
+	}
+
+	if err := r.Notify(context.Background(), ev); err != nil {
+		t.Fatal(err)
+	}
+	if next.calls != 2 {
+		t.Errorf("calls after exceeding burst = %d, want 2 (dropped)", next.calls)
+	}
+}
+
+func TestRateLimiterSeparatesKeys(t *testing.T) {
+	next := &countingNotifier{}
+	r := NewRateLimiter(next, time.Minute, 1)
+	defer r.Close()
+
+	r.Notify(context.Background(), Event{EID: 1, Type: Started})
+	r.Notify(context.Background(), Event{EID: 2, Type: Started})
+	r.Notify(context.Background(), Event{EID: 1, Type: Suspended})
+
+	if next.calls != 3 {
+		t.Errorf("calls = %d, want 3 (distinct EID/Type pairs)", next.calls)
+	}
+}
+
+func TestRateLimiterEvictsStaleKeys(t *testing.T) {
+	next := &countingNotifier{}
+	r := NewRateLimiter(next, time.Millisecond, 1)
+	defer r.Close()
+
+	r.Notify(context.Background(), Event{EID: 1, Type: Started})
+
+	r.mu.Lock()
+	n := len(r.counts)
+	r.mu.Unlock()
+	if n != 1 {
+		t.Fatalf("counts has %d entries after Notify, want 1", n)
+	}
+
+	time.Sleep(2 * time.Millisecond)
+	r.evictStale()
+
+	r.mu.Lock()
+	n = len(r.counts)
+	r.mu.Unlock()
+	if n != 0 {
+		t.Errorf("counts has %d entries after evictStale, want 0", n)
+	}
+}
@@ -0,0 +1,30 @@
+package ankiddie
+
+import "sync"
+
+// maxOutTailBytes bounds how much captured output an outTailBuffer retains
+const maxOutTailBytes = 4096
+
+// outTailBuffer retains the most recently written bytes, for inclusion in
+// notify.Event.OutTail so sinks can see what a script printed right before it errored
+type outTailBuffer struct {
+	mu  sync.Mutex
+	buf []byte
+}
+
+// Write appends s to the buffer, trimming from the front if it grows past maxOutTailBytes
+func (t *outTailBuffer) Write(s string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.buf = append(t.buf, s...)
+	if over := len(t.buf) - maxOutTailBytes; over > 0 {
+		t.buf = t.buf[over:]
+	}
+}
+
+// String returns a snapshot of the buffer's current contents
+func (t *outTailBuffer) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return string(t.buf)
+}
@@ -0,0 +1,136 @@
+package persist
+
+import (
+	"encoding/json"
+
+	"github.com/boltdb/bolt"
+
+	"github.com/gbl08ma/ankiddie"
+)
+
+var (
+	scriptsBucket = []byte("scripts")
+	paramsBucket  = []byte("params")
+)
+
+// BoltStore persists scripts and their parameter values in a boltdb database file
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// NewBoltStore opens (creating if necessary) a bolt database at path
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(scriptsBucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucketIfNotExists(paramsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &BoltStore{db: db}, nil
+}
+
+// Close closes the underlying bolt database
+func (bs *BoltStore) Close() error {
+	return bs.db.Close()
+}
+
+// GetScript implements ankiddie.ScriptLoader
+func (bs *BoltStore) GetScript(id string) (*ankiddie.Script, error) {
+	var script ankiddie.Script
+	err := bs.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(scriptsBucket).Get([]byte(id))
+		if data == nil {
+			return ErrScriptNotFound
+		}
+		return json.Unmarshal(data, &script)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &script, nil
+}
+
+// GetAutorunScripts implements ankiddie.ScriptLoader
+func (bs *BoltStore) GetAutorunScripts(autorunLevel int) ([]*ankiddie.Script, error) {
+	var scripts []*ankiddie.Script
+	err := bs.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(scriptsBucket).ForEach(func(k, v []byte) error {
+			var script ankiddie.Script
+			if err := json.Unmarshal(v, &script); err != nil {
+				return err
+			}
+			if script.Autorun == autorunLevel {
+				scripts = append(scripts, &script)
+			}
+			return nil
+		})
+	})
+	return scripts, err
+}
+
+// StoreScript implements ankiddie.ScriptStorer
+func (bs *BoltStore) StoreScript(script *ankiddie.Script) error {
+	data, err := json.Marshal(script)
+	if err != nil {
+		return err
+	}
+	return bs.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(scriptsBucket).Put([]byte(script.ID), data)
+	})
+}
+
+// ListScripts implements persist.ScriptLister
+func (bs *BoltStore) ListScripts() ([]*ankiddie.Script, error) {
+	var scripts []*ankiddie.Script
+	err := bs.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(scriptsBucket).ForEach(func(k, v []byte) error {
+			var script ankiddie.Script
+			if err := json.Unmarshal(v, &script); err != nil {
+				return err
+			}
+			scripts = append(scripts, &script)
+			return nil
+		})
+	})
+	return scripts, err
+}
+
+// DeleteScript implements persist.ScriptDeleter
+func (bs *BoltStore) DeleteScript(id string) error {
+	return bs.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(scriptsBucket).Delete([]byte(id))
+	})
+}
+
+// LoadParams implements the parameter persistence ankiddie.ScriptPersister requires
+func (bs *BoltStore) LoadParams(scriptID string) (map[string]interface{}, error) {
+	var values map[string]interface{}
+	err := bs.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(paramsBucket).Get([]byte(scriptID))
+		if data == nil {
+			return nil
+		}
+		return json.Unmarshal(data, &values)
+	})
+	return values, err
+}
+
+// StoreParams implements the parameter persistence ankiddie.ScriptPersister requires
+func (bs *BoltStore) StoreParams(scriptID string, values map[string]interface{}) error {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	return bs.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(paramsBucket).Put([]byte(scriptID), data)
+	})
+}
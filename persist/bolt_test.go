@@ -0,0 +1,72 @@
+package persist_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/gbl08ma/ankiddie"
+	"github.com/gbl08ma/ankiddie/persist"
+)
+
+func TestBoltStoreScriptAndParamRoundTrip(t *testing.T) {
+	bs, err := persist.NewBoltStore(filepath.Join(t.TempDir(), "ankiddie.bolt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer bs.Close()
+
+	script := &ankiddie.Script{ID: "greet", Autorun: 1, Code: "println(\"hi\")"}
+	if err := bs.StoreScript(script); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := bs.GetScript("greet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Code != script.Code {
+		t.Errorf("Code = %q, want %q", got.Code, script.Code)
+	}
+
+	values := map[string]interface{}{"timeout": "5s"}
+	if err := bs.StoreParams("greet", values); err != nil {
+		t.Fatal(err)
+	}
+	gotValues, err := bs.LoadParams("greet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotValues["timeout"] != values["timeout"] {
+		t.Errorf("LoadParams = %v, want %v", gotValues, values)
+	}
+
+	if err := bs.DeleteScript("greet"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bs.GetScript("greet"); err != persist.ErrScriptNotFound {
+		t.Errorf("GetScript after delete err = %v, want ErrScriptNotFound", err)
+	}
+}
+
+func TestBoltStoreGetAutorunScripts(t *testing.T) {
+	bs, err := persist.NewBoltStore(filepath.Join(t.TempDir(), "ankiddie.bolt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer bs.Close()
+
+	if err := bs.StoreScript(&ankiddie.Script{ID: "a", Autorun: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := bs.StoreScript(&ankiddie.Script{ID: "b", Autorun: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	scripts, err := bs.GetAutorunScripts(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scripts) != 1 || scripts[0].ID != "a" {
+		t.Errorf("GetAutorunScripts(1) = %v, want [a]", scripts)
+	}
+}
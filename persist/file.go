@@ -0,0 +1,174 @@
+package persist
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/gbl08ma/ankiddie"
+)
+
+// FileStore persists scripts and their parameter values as one JSON file per
+// script under a directory, and is meant for single-operator, single-process use
+type FileStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// fileRecord is what gets marshaled to <dir>/<id>.json
+type fileRecord struct {
+	Script *ankiddie.Script       `json:"script"`
+	Params map[string]interface{} `json:"params,omitempty"`
+}
+
+// NewFileStore returns a FileStore rooted at dir, creating it if it does not exist
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (fs *FileStore) path(id string) string {
+	return filepath.Join(fs.dir, id+".json")
+}
+
+func (fs *FileStore) read(id string) (*fileRecord, error) {
+	data, err := os.ReadFile(fs.path(id))
+	if os.IsNotExist(err) {
+		return nil, ErrScriptNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rec fileRecord
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+func (fs *FileStore) write(id string, rec *fileRecord) error {
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fs.path(id), data, 0644)
+}
+
+// GetScript implements ankiddie.ScriptLoader
+func (fs *FileStore) GetScript(id string) (*ankiddie.Script, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	rec, err := fs.read(id)
+	if err != nil {
+		return nil, err
+	}
+	return rec.Script, nil
+}
+
+// GetAutorunScripts implements ankiddie.ScriptLoader
+func (fs *FileStore) GetAutorunScripts(autorunLevel int) ([]*ankiddie.Script, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	entries, err := os.ReadDir(fs.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var scripts []*ankiddie.Script
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		id := entry.Name()[:len(entry.Name())-len(".json")]
+		rec, err := fs.read(id)
+		if err != nil {
+			return nil, err
+		}
+		if rec.Script.Autorun == autorunLevel {
+			scripts = append(scripts, rec.Script)
+		}
+	}
+	return scripts, nil
+}
+
+// StoreScript implements ankiddie.ScriptStorer
+func (fs *FileStore) StoreScript(script *ankiddie.Script) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	rec, err := fs.read(script.ID)
+	if err != nil && err != ErrScriptNotFound {
+		return err
+	}
+	if rec == nil {
+		rec = &fileRecord{}
+	}
+	rec.Script = script
+	return fs.write(script.ID, rec)
+}
+
+// ListScripts implements persist.ScriptLister
+func (fs *FileStore) ListScripts() ([]*ankiddie.Script, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	entries, err := os.ReadDir(fs.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var scripts []*ankiddie.Script
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		id := entry.Name()[:len(entry.Name())-len(".json")]
+		rec, err := fs.read(id)
+		if err != nil {
+			return nil, err
+		}
+		scripts = append(scripts, rec.Script)
+	}
+	return scripts, nil
+}
+
+// DeleteScript implements persist.ScriptDeleter
+func (fs *FileStore) DeleteScript(id string) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	err := os.Remove(fs.path(id))
+	if os.IsNotExist(err) {
+		return ErrScriptNotFound
+	}
+	return err
+}
+
+// LoadParams implements the parameter persistence ankiddie.ScriptPersister requires
+func (fs *FileStore) LoadParams(scriptID string) (map[string]interface{}, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	rec, err := fs.read(scriptID)
+	if err == ErrScriptNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rec.Params, nil
+}
+
+// StoreParams implements the parameter persistence ankiddie.ScriptPersister requires
+func (fs *FileStore) StoreParams(scriptID string, values map[string]interface{}) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	rec, err := fs.read(scriptID)
+	if err != nil {
+		return err
+	}
+	rec.Params = values
+	return fs.write(scriptID, rec)
+}
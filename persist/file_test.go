@@ -0,0 +1,104 @@
+package persist_test
+
+import (
+	"testing"
+
+	"github.com/gbl08ma/ankiddie"
+	"github.com/gbl08ma/ankiddie/persist"
+)
+
+func TestFileStoreScriptRoundTrip(t *testing.T) {
+	fs, err := persist.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	script := &ankiddie.Script{ID: "greet", Autorun: 1, Code: "println(\"hi\")"}
+	if err := fs.StoreScript(script); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := fs.GetScript("greet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Code != script.Code {
+		t.Errorf("Code = %q, want %q", got.Code, script.Code)
+	}
+
+	scripts, err := fs.GetAutorunScripts(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scripts) != 1 || scripts[0].ID != "greet" {
+		t.Errorf("GetAutorunScripts(1) = %v, want [greet]", scripts)
+	}
+
+	if scripts, err := fs.GetAutorunScripts(2); err != nil || len(scripts) != 0 {
+		t.Errorf("GetAutorunScripts(2) = %v, %v, want empty, nil", scripts, err)
+	}
+}
+
+func TestFileStoreGetScriptNotFound(t *testing.T) {
+	fs, err := persist.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fs.GetScript("missing"); err != persist.ErrScriptNotFound {
+		t.Errorf("GetScript(missing) err = %v, want ErrScriptNotFound", err)
+	}
+}
+
+func TestFileStoreParamsRoundTrip(t *testing.T) {
+	fs, err := persist.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.StoreScript(&ankiddie.Script{ID: "withparams"}); err != nil {
+		t.Fatal(err)
+	}
+
+	values := map[string]interface{}{"timeout": "5s"}
+	if err := fs.StoreParams("withparams", values); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := fs.LoadParams("withparams")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got["timeout"] != values["timeout"] {
+		t.Errorf("LoadParams = %v, want %v", got, values)
+	}
+}
+
+func TestFileStoreListAndDeleteScript(t *testing.T) {
+	fs, err := persist.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.StoreScript(&ankiddie.Script{ID: "a"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.StoreScript(&ankiddie.Script{ID: "b"}); err != nil {
+		t.Fatal(err)
+	}
+
+	scripts, err := fs.ListScripts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scripts) != 2 {
+		t.Fatalf("ListScripts() = %d scripts, want 2", len(scripts))
+	}
+
+	if err := fs.DeleteScript("a"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fs.GetScript("a"); err != persist.ErrScriptNotFound {
+		t.Errorf("GetScript(a) after delete err = %v, want ErrScriptNotFound", err)
+	}
+	if err := fs.DeleteScript("a"); err != persist.ErrScriptNotFound {
+		t.Errorf("DeleteScript(a) twice err = %v, want ErrScriptNotFound", err)
+	}
+}
@@ -0,0 +1,24 @@
+// Package persist provides ready-made ankiddie.ScriptPersister implementations,
+// so consumers of cmd/ankiddie are not required to bring their own storage layer.
+package persist
+
+import (
+	"errors"
+
+	"github.com/gbl08ma/ankiddie"
+)
+
+// ErrScriptNotFound is returned by GetScript when no script with the given ID exists
+var ErrScriptNotFound = errors.New("persist: script not found")
+
+// ScriptLister is implemented by persisters that can enumerate every stored script.
+// It is not part of ankiddie.ScriptPersister since not every backing store can do this cheaply.
+type ScriptLister interface {
+	ListScripts() ([]*ankiddie.Script, error)
+}
+
+// ScriptDeleter is implemented by persisters that can remove a stored script.
+// It is not part of ankiddie.ScriptPersister since deletion is an operator concern, not a runtime one.
+type ScriptDeleter interface {
+	DeleteScript(id string) error
+}
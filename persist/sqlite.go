@@ -0,0 +1,159 @@
+package persist
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/gbl08ma/ankiddie"
+)
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS scripts (
+	id TEXT PRIMARY KEY,
+	autorun INTEGER NOT NULL,
+	data TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS params (
+	script_id TEXT PRIMARY KEY,
+	data TEXT NOT NULL
+);
+`
+
+// SQLiteStore persists scripts and their parameter values in a SQLite database file
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore opens (creating and migrating if necessary) a SQLite database at path
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &SQLiteStore{db: db}, nil
+}
+
+// Close closes the underlying database handle
+func (ss *SQLiteStore) Close() error {
+	return ss.db.Close()
+}
+
+// GetScript implements ankiddie.ScriptLoader
+func (ss *SQLiteStore) GetScript(id string) (*ankiddie.Script, error) {
+	var data string
+	err := ss.db.QueryRow(`SELECT data FROM scripts WHERE id = ?`, id).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, ErrScriptNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var script ankiddie.Script
+	if err := json.Unmarshal([]byte(data), &script); err != nil {
+		return nil, err
+	}
+	return &script, nil
+}
+
+// GetAutorunScripts implements ankiddie.ScriptLoader
+func (ss *SQLiteStore) GetAutorunScripts(autorunLevel int) ([]*ankiddie.Script, error) {
+	rows, err := ss.db.Query(`SELECT data FROM scripts WHERE autorun = ?`, autorunLevel)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scripts []*ankiddie.Script
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		var script ankiddie.Script
+		if err := json.Unmarshal([]byte(data), &script); err != nil {
+			return nil, err
+		}
+		scripts = append(scripts, &script)
+	}
+	return scripts, rows.Err()
+}
+
+// StoreScript implements ankiddie.ScriptStorer
+func (ss *SQLiteStore) StoreScript(script *ankiddie.Script) error {
+	data, err := json.Marshal(script)
+	if err != nil {
+		return err
+	}
+	_, err = ss.db.Exec(
+		`INSERT INTO scripts (id, autorun, data) VALUES (?, ?, ?)
+		 ON CONFLICT(id) DO UPDATE SET autorun = excluded.autorun, data = excluded.data`,
+		script.ID, script.Autorun, data,
+	)
+	return err
+}
+
+// ListScripts implements persist.ScriptLister
+func (ss *SQLiteStore) ListScripts() ([]*ankiddie.Script, error) {
+	rows, err := ss.db.Query(`SELECT data FROM scripts`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scripts []*ankiddie.Script
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		var script ankiddie.Script
+		if err := json.Unmarshal([]byte(data), &script); err != nil {
+			return nil, err
+		}
+		scripts = append(scripts, &script)
+	}
+	return scripts, rows.Err()
+}
+
+// DeleteScript implements persist.ScriptDeleter
+func (ss *SQLiteStore) DeleteScript(id string) error {
+	_, err := ss.db.Exec(`DELETE FROM scripts WHERE id = ?`, id)
+	return err
+}
+
+// LoadParams implements the parameter persistence ankiddie.ScriptPersister requires
+func (ss *SQLiteStore) LoadParams(scriptID string) (map[string]interface{}, error) {
+	var data string
+	err := ss.db.QueryRow(`SELECT data FROM params WHERE script_id = ?`, scriptID).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var values map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// StoreParams implements the parameter persistence ankiddie.ScriptPersister requires
+func (ss *SQLiteStore) StoreParams(scriptID string, values map[string]interface{}) error {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	_, err = ss.db.Exec(
+		`INSERT INTO params (script_id, data) VALUES (?, ?)
+		 ON CONFLICT(script_id) DO UPDATE SET data = excluded.data`,
+		scriptID, data,
+	)
+	return err
+}
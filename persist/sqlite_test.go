@@ -0,0 +1,51 @@
+package persist_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/gbl08ma/ankiddie"
+	"github.com/gbl08ma/ankiddie/persist"
+)
+
+func TestSQLiteStoreScriptAndParamRoundTrip(t *testing.T) {
+	ss, err := persist.NewSQLiteStore(filepath.Join(t.TempDir(), "ankiddie.sqlite"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	script := &ankiddie.Script{ID: "greet", Autorun: 1, Code: "println(\"hi\")"}
+	if err := ss.StoreScript(script); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ss.GetScript("greet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Code != script.Code {
+		t.Errorf("Code = %q, want %q", got.Code, script.Code)
+	}
+
+	values := map[string]interface{}{"timeout": "5s"}
+	if err := ss.StoreParams("greet", values); err != nil {
+		t.Fatal(err)
+	}
+	gotValues, err := ss.LoadParams("greet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotValues["timeout"] != values["timeout"] {
+		t.Errorf("LoadParams = %v, want %v", gotValues, values)
+	}
+}
+
+func TestSQLiteStoreGetScriptNotFound(t *testing.T) {
+	ss, err := persist.NewSQLiteStore(filepath.Join(t.TempDir(), "ankiddie.sqlite"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ss.GetScript("missing"); err != persist.ErrScriptNotFound {
+		t.Errorf("GetScript(missing) err = %v, want ErrScriptNotFound", err)
+	}
+}
@@ -1,5 +1,10 @@
 package ankiddie
 
+import (
+	"github.com/gbl08ma/ankiddie/config"
+	"github.com/gbl08ma/ankiddie/notify"
+)
+
 // Script contains dynamic behavior for the system to implement at run time
 type Script struct {
 	ID      string
@@ -7,6 +12,17 @@ type Script struct {
 	Autorun int
 	Code    string
 	Notes   string
+	// Params declares the typed parameters this script exposes to operators via param()
+	Params []config.ParamDecl
+	// NotifyPolicy overrides which lifecycle/error events this script's environments raise.
+	// A nil value lets every event through.
+	NotifyPolicy *notify.Policy
+}
+
+// ParamSchema renders this script's declared parameters as a JSON Schema document,
+// for a UI or the HTTP API to render a form from
+func (s *Script) ParamSchema() ([]byte, error) {
+	return config.Schema(s.Params)
 }
 
 // ScriptLoader loads scripts from storage
@@ -25,4 +41,8 @@ type ScriptStorer interface {
 type ScriptPersister interface {
 	ScriptLoader
 	ScriptStorer
+	// LoadParams loads the persisted parameter values for the given script, if any
+	LoadParams(scriptID string) (map[string]interface{}, error)
+	// StoreParams persists the parameter values for the given script
+	StoreParams(scriptID string, values map[string]interface{}) error
 }
@@ -0,0 +1,42 @@
+// Package scripttest lets script authors declaratively test anko scripts
+// running inside an ankiddie.Environment, without hand-writing Go tests for every script.
+package scripttest
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/gbl08ma/ankiddie"
+)
+
+// Step is a single piece of code executed as part of a Case, together with the
+// expectations checked against the environment immediately after it runs
+type Step struct {
+	// Name identifies the step in reports; defaults to its index if empty
+	Name string
+	// Input is the code fragment fed to Environment.Execute
+	Input string
+	// ExpectOutput, if set, must match the output captured via println/print/printf during this step
+	ExpectOutput *regexp.Regexp
+	// ExpectResult, if set, is deep-equal compared against the value Execute returned
+	ExpectResult interface{}
+	// ExpectVars, if set, is looked up in the environment after the step runs
+	ExpectVars map[string]interface{}
+	// Timeout bounds how long the step is allowed to run; zero means no timeout
+	Timeout time.Duration
+	// Setup runs before Input, if set
+	Setup func(*ankiddie.Environment) error
+	// Teardown runs after the step's expectations are checked, even if they failed
+	Teardown func(*ankiddie.Environment) error
+}
+
+// Case is a sequence of Steps run against a single Environment, in order, so that
+// later steps can exercise state left behind by earlier ones
+type Case struct {
+	// Name identifies the case in reports
+	Name string
+	// Code is the initial source the environment is created with; may be empty
+	Code string
+	// Steps are executed in order against the same environment
+	Steps []Step
+}
@@ -0,0 +1,75 @@
+package scripttest
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"time"
+)
+
+// StepResult records the outcome of running a single Step
+type StepResult struct {
+	Name     string        `json:"name"`
+	Passed   bool          `json:"passed"`
+	Duration time.Duration `json:"duration"`
+	Output   string        `json:"output,omitempty"`
+	Got      interface{}   `json:"got,omitempty"`
+	Err      string        `json:"error,omitempty"`
+}
+
+// CaseResult records the outcome of running every Step in a Case
+type CaseResult struct {
+	Name   string       `json:"name"`
+	Passed bool         `json:"passed"`
+	Steps  []StepResult `json:"steps"`
+}
+
+// Report is the outcome of running a Runner over a set of Cases
+type Report struct {
+	Cases  []CaseResult `json:"cases"`
+	Passed int          `json:"passed"`
+	Failed int          `json:"failed"`
+}
+
+// junitTestsuite mirrors the subset of the JUnit XML schema consumed by most CI systems
+type junitTestsuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name    string        `xml:"name,attr"`
+	Time    float64       `xml:"time,attr"`
+	Failure *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// ToJUnitXML renders the report as a JUnit-style XML document, one testcase per Step
+func (r *Report) ToJUnitXML() ([]byte, error) {
+	suite := junitTestsuite{}
+	for _, c := range r.Cases {
+		for _, s := range c.Steps {
+			tc := junitTestCase{
+				Name: c.Name + "/" + s.Name,
+				Time: s.Duration.Seconds(),
+			}
+			suite.Tests++
+			if !s.Passed {
+				suite.Failures++
+				tc.Failure = &junitFailure{Message: s.Err, Text: s.Output}
+			}
+			suite.TestCases = append(suite.TestCases, tc)
+		}
+	}
+	return xml.MarshalIndent(suite, "", "  ")
+}
+
+// ToJSON renders the report as JSON, including the actual-vs-expected detail for every step
+func (r *Report) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
@@ -0,0 +1,161 @@
+package scripttest
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gbl08ma/ankiddie"
+)
+
+// Runner executes Cases against environments it creates on its own Ankiddie instance
+type Runner struct {
+	ssys *ankiddie.Ankiddie
+}
+
+// NewRunner returns a Runner that creates its environments on ssys
+func NewRunner(ssys *ankiddie.Ankiddie) *Runner {
+	return &Runner{ssys: ssys}
+}
+
+// Run executes every Case in order, each against its own fresh Environment, and
+// returns a Report describing the outcome of every Step
+func (r *Runner) Run(cases []Case) *Report {
+	report := &Report{}
+	for _, c := range cases {
+		cr := r.runCase(c)
+		report.Cases = append(report.Cases, cr)
+		if cr.Passed {
+			report.Passed++
+		} else {
+			report.Failed++
+		}
+	}
+	return report
+}
+
+func (r *Runner) runCase(c Case) CaseResult {
+	cr := CaseResult{Name: c.Name, Passed: true}
+
+	var buf strings.Builder
+	var mu sync.Mutex
+	out := func(env *ankiddie.Environment, msg string) error {
+		mu.Lock()
+		buf.WriteString(msg)
+		mu.Unlock()
+		return nil
+	}
+
+	env := r.ssys.NewEnvWithCode(c.Code, out)
+	defer env.Forget()
+	if c.Code != "" {
+		if _, err := env.Start(); err != nil {
+			cr.Passed = false
+			cr.Steps = append(cr.Steps, StepResult{Name: "setup", Passed: false, Err: err.Error()})
+			return cr
+		}
+	}
+
+	for i, step := range c.Steps {
+		name := step.Name
+		if name == "" {
+			name = fmt.Sprintf("step%d", i)
+		}
+
+		sr := r.runStep(env, step, name, &buf, &mu)
+		cr.Steps = append(cr.Steps, sr)
+		if !sr.Passed {
+			cr.Passed = false
+		}
+	}
+
+	return cr
+}
+
+func (r *Runner) runStep(env *ankiddie.Environment, step Step, name string, buf *strings.Builder, mu *sync.Mutex) (sr StepResult) {
+	sr = StepResult{Name: name}
+
+	if step.Setup != nil {
+		if err := step.Setup(env); err != nil {
+			sr.Err = fmt.Sprintf("setup: %s", err)
+			return sr
+		}
+	}
+	if step.Teardown != nil {
+		defer func() {
+			if err := step.Teardown(env); err != nil && sr.Err == "" {
+				sr.Err = fmt.Sprintf("teardown: %s", err)
+				sr.Passed = false
+			}
+		}()
+	}
+
+	mu.Lock()
+	buf.Reset()
+	mu.Unlock()
+
+	start := time.Now()
+	result, err := r.execute(env, step)
+	sr.Duration = time.Since(start)
+
+	mu.Lock()
+	sr.Output = buf.String()
+	mu.Unlock()
+
+	if err != nil {
+		sr.Err = err.Error()
+		return sr
+	}
+	sr.Got = result
+
+	if step.ExpectOutput != nil && !step.ExpectOutput.MatchString(sr.Output) {
+		sr.Err = fmt.Sprintf("output %q does not match %s", sr.Output, step.ExpectOutput)
+		return sr
+	}
+	if step.ExpectResult != nil && !reflect.DeepEqual(result, step.ExpectResult) {
+		sr.Err = fmt.Sprintf("result %#v does not equal expected %#v", result, step.ExpectResult)
+		return sr
+	}
+	for varName, expected := range step.ExpectVars {
+		got, err := env.Get(varName)
+		if err != nil {
+			sr.Err = fmt.Sprintf("var %s: %s", varName, err)
+			return sr
+		}
+		if !reflect.DeepEqual(got, expected) {
+			sr.Err = fmt.Sprintf("var %s = %#v, expected %#v", varName, got, expected)
+			return sr
+		}
+	}
+
+	sr.Passed = true
+	return sr
+}
+
+func (r *Runner) execute(env *ankiddie.Environment, step Step) (interface{}, error) {
+	if step.Timeout <= 0 {
+		return env.Execute(step.Input, false)
+	}
+
+	type execResult struct {
+		val interface{}
+		err error
+	}
+	done := make(chan execResult, 1)
+	go func() {
+		val, err := env.Execute(step.Input, false)
+		done <- execResult{val, err}
+	}()
+
+	select {
+	case res := <-done:
+		return res.val, res.err
+	case <-time.After(step.Timeout):
+		// cancel the running code; the next Execute on env starts a fresh context
+		env.Suspend()
+		return nil, context.DeadlineExceeded
+	}
+}
@@ -0,0 +1,105 @@
+package scripttest_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/gbl08ma/ankiddie"
+	"github.com/gbl08ma/ankiddie/scripttest"
+)
+
+func TestRunnerPassingCase(t *testing.T) {
+	ssys := ankiddie.New(nil, nil)
+	r := scripttest.NewRunner(ssys)
+
+	report := r.Run([]scripttest.Case{
+		{
+			Name: "arithmetic",
+			Steps: []scripttest.Step{
+				{Name: "add", Input: "1 + 1", ExpectResult: int64(2)},
+				{Name: "print", Input: `println("hi")`, ExpectOutput: regexp.MustCompile(`^hi\n$`)},
+			},
+		},
+	})
+
+	if report.Failed != 0 || report.Passed != 1 {
+		t.Fatalf("report = %+v, want 1 passed, 0 failed", report)
+	}
+	for _, step := range report.Cases[0].Steps {
+		if !step.Passed {
+			t.Errorf("step %s failed: %s", step.Name, step.Err)
+		}
+	}
+}
+
+func TestRunnerFailingExpectation(t *testing.T) {
+	ssys := ankiddie.New(nil, nil)
+	r := scripttest.NewRunner(ssys)
+
+	report := r.Run([]scripttest.Case{
+		{
+			Name: "wrong-expectation",
+			Steps: []scripttest.Step{
+				{Name: "add", Input: "1 + 1", ExpectResult: int64(3)},
+			},
+		},
+	})
+
+	if report.Failed != 1 || report.Passed != 0 {
+		t.Fatalf("report = %+v, want 1 failed, 0 passed", report)
+	}
+	if report.Cases[0].Steps[0].Passed {
+		t.Error("step with wrong ExpectResult reported as passed")
+	}
+}
+
+func TestRunnerExpectVars(t *testing.T) {
+	ssys := ankiddie.New(nil, nil)
+	r := scripttest.NewRunner(ssys)
+
+	report := r.Run([]scripttest.Case{
+		{
+			Name: "vars",
+			Steps: []scripttest.Step{
+				{Name: "assign", Input: "x = 42"},
+				{Name: "check", Input: "x", ExpectVars: map[string]interface{}{"x": int64(42)}},
+			},
+		},
+	})
+
+	if report.Failed != 0 {
+		t.Fatalf("report = %+v, want 0 failed", report)
+	}
+}
+
+func TestRunnerFailingTeardown(t *testing.T) {
+	ssys := ankiddie.New(nil, nil)
+	r := scripttest.NewRunner(ssys)
+
+	report := r.Run([]scripttest.Case{
+		{
+			Name: "bad-teardown",
+			Steps: []scripttest.Step{
+				{
+					Name:  "add",
+					Input: "1 + 1",
+					Teardown: func(env *ankiddie.Environment) error {
+						return errors.New("teardown boom")
+					},
+				},
+			},
+		},
+	})
+
+	if report.Passed != 0 || report.Failed != 1 {
+		t.Fatalf("report = %+v, want 1 failed, 0 passed", report)
+	}
+	step := report.Cases[0].Steps[0]
+	if step.Passed {
+		t.Error("step with a failing Teardown reported as passed")
+	}
+	if step.Err == "" {
+		t.Error("step.Err is empty, want the teardown error to be recorded")
+	}
+}
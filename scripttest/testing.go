@@ -0,0 +1,23 @@
+package scripttest
+
+import (
+	"testing"
+
+	"github.com/gbl08ma/ankiddie"
+)
+
+// RunT runs cases against ssys and reports failures through t, one Go subtest per Case
+func RunT(t *testing.T, ssys *ankiddie.Ankiddie, cases []Case) {
+	r := NewRunner(ssys)
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			cr := r.runCase(c)
+			for _, sr := range cr.Steps {
+				if !sr.Passed {
+					t.Errorf("%s: %s", sr.Name, sr.Err)
+				}
+			}
+		})
+	}
+}